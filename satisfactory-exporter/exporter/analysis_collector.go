@@ -0,0 +1,149 @@
+package exporter
+
+import (
+	"context"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/justereseau/satisfactory-metrics/satisfactory-exporter/frm"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// States of a factory building, see machineState.
+const (
+	MachineOK            = "ok"
+	MachineInputStarved  = "input_starved"
+	MachineOutputBlocked = "output_blocked"
+	MachineUnpowered     = "unpowered"
+	MachinePaused        = "paused"
+	MachineUnconfigured  = "unconfigured"
+)
+
+// AnalysisCollector classifies the factory buildings to find the broken
+// production lines.
+type AnalysisCollector struct {
+	source           Source
+	threshold        float64
+	geohashPrecision int
+	logger           log.Logger
+}
+
+// NewAnalysisCollector creates an AnalysisCollector. A building running below
+// threshold percent is not ok, the areas are the first geohashPrecision
+// characters of the geohash of the buildings.
+func NewAnalysisCollector(source Source, threshold float64, geohashPrecision int, logger log.Logger) *AnalysisCollector {
+	return &AnalysisCollector{
+		source:           source,
+		threshold:        threshold,
+		geohashPrecision: geohashPrecision,
+		logger:           logger,
+	}
+}
+
+type machineGroup struct {
+	recipe   string
+	building string
+	state    string
+}
+
+type machineArea struct {
+	geohash string
+	state   string
+}
+
+func (c *AnalysisCollector) Update(ctx context.Context, ch chan<- prometheus.Metric) error {
+	details, err := frm.Routes{Getter: c.source}.GetFactory(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Without the circuits, unpowered buildings are reported as starved.
+	circuits, err := frm.Routes{Getter: c.source}.GetPower(ctx)
+	if err != nil {
+		level.Warn(c.logger).Log("msg", "Error getting power circuits, unpowered buildings are not detected", "err", err)
+		circuits = nil
+	}
+	var powered map[float64]bool
+	if circuits != nil {
+		powered = map[float64]bool{}
+		for _, circuit := range circuits {
+			powered[circuit.CircuitId] = !circuit.FuseTriggered
+		}
+	}
+
+	counts := map[machineGroup]float64{}
+	wasted := map[machineGroup]float64{}
+	areas := map[machineArea]float64{}
+	for _, building := range details {
+		state := c.machineState(building, powered)
+		group := machineGroup{recipe: building.Recipe, building: building.Building, state: state}
+		counts[group]++
+		if state != MachineOK {
+			wasted[group] += building.PowerInfo.PowerConsumed
+		}
+
+		gh, _, _, _ := locationLabels(building.Location)
+		if c.geohashPrecision > 0 && len(gh) > c.geohashPrecision {
+			gh = gh[:c.geohashPrecision]
+		}
+		areas[machineArea{geohash: gh, state: state}]++
+	}
+
+	for group, count := range counts {
+		ch <- prometheus.MustNewConstMetric(MachineStateCount, prometheus.GaugeValue, count, group.recipe, group.building, group.state)
+	}
+	for group, power := range wasted {
+		ch <- prometheus.MustNewConstMetric(MachinePowerWasted, prometheus.GaugeValue, power, group.recipe, group.building, group.state)
+	}
+	for area, count := range areas {
+		ch <- prometheus.MustNewConstMetric(MachineStateAreaCount, prometheus.GaugeValue, count, area.geohash, area.state)
+	}
+	return nil
+}
+
+// machineState classifies a building. FRM reports the amounts waiting in the
+// buffers of the buildings but not their capacity, so a building is blocked
+// when its ingredients are stocked while it is stalled or producing below its
+// maximum rate. Otherwise a building consuming an ingredient below its maximum
+// rate, or stalled entirely, is starved.
+func (c *AnalysisCollector) machineState(building frm.BuildingDetail, powered map[float64]bool) string {
+	switch {
+	case !building.IsConfigured:
+		return MachineUnconfigured
+	case building.IsPaused:
+		return MachinePaused
+	case powered != nil && !powered[building.PowerInfo.CircuitId]:
+		return MachineUnpowered
+	}
+
+	if ingredientsStocked(building.Ingredients) {
+		if !building.IsProducing {
+			return MachineOutputBlocked
+		}
+		for _, prod := range building.Production {
+			if prod.ProdPercent < c.threshold {
+				return MachineOutputBlocked
+			}
+		}
+	}
+	for _, ingredient := range building.Ingredients {
+		if ingredient.ConsPercent < c.threshold {
+			return MachineInputStarved
+		}
+	}
+	if !building.IsProducing {
+		return MachineInputStarved
+	}
+	return MachineOK
+}
+
+// ingredientsStocked tells whether every ingredient of a building is waiting
+// in its input buffer.
+func ingredientsStocked(ingredients []frm.Ingredient) bool {
+	for _, ingredient := range ingredients {
+		if ingredient.Amount <= 0 {
+			return false
+		}
+	}
+	return true
+}
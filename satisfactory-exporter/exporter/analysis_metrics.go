@@ -0,0 +1,33 @@
+package exporter
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	MachineStateCount = RegisterNewGaugeVec(prometheus.GaugeOpts{
+		Name: "machine_state_count",
+		Help: "Number of factory buildings per recipe and state: ok, input_starved, output_blocked, unpowered, paused or unconfigured",
+	}, []string{
+		"recipe",
+		"machine_name",
+		"state",
+	})
+
+	MachineStateAreaCount = RegisterNewGaugeVec(prometheus.GaugeOpts{
+		Name: "machine_state_area_count",
+		Help: "Number of factory buildings per area, the geohash prefix, and state",
+	}, []string{
+		"geohash",
+		"state",
+	})
+
+	MachinePowerWasted = RegisterNewGaugeVec(prometheus.GaugeOpts{
+		Name: "machine_power_wasted",
+		Help: "Power drawn in MW by factory buildings that are not producing at full rate, per recipe and state",
+	}, []string{
+		"recipe",
+		"machine_name",
+		"state",
+	})
+)
@@ -0,0 +1,64 @@
+package exporter
+
+import (
+	"context"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/justereseau/satisfactory-metrics/satisfactory-exporter/frm"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// BalanceCollector compares the production and the consumption of the items,
+// and checks the production of the critical items against their target.
+type BalanceCollector struct {
+	source  Source
+	targets map[string]float64
+	logger  log.Logger
+}
+
+// NewBalanceCollector creates a BalanceCollector, targets is the production
+// per minute expected for each critical item.
+func NewBalanceCollector(source Source, targets map[string]float64, logger log.Logger) *BalanceCollector {
+	return &BalanceCollector{
+		source:  source,
+		targets: targets,
+		logger:  logger,
+	}
+}
+
+func (c *BalanceCollector) Update(ctx context.Context, ch chan<- prometheus.Metric) error {
+	details, err := frm.Routes{Getter: c.source}.GetProdStats(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Without the stock, the depletion time is not reported.
+	inventory, err := frm.Routes{Getter: c.source}.GetWorldInv(ctx)
+	if err != nil {
+		level.Warn(c.logger).Log("msg", "Error getting world inventory, stock depletion is not reported", "err", err)
+		inventory = nil
+	}
+	stock := map[string]float64{}
+	for _, item := range inventory {
+		stock[item.Name] += item.Amount
+	}
+
+	produced := map[string]float64{}
+	for _, d := range details {
+		net := d.CurrentProduction - d.CurrentConsumption
+		produced[d.ItemName] = d.CurrentProduction
+
+		ch <- prometheus.MustNewConstMetric(ItemNetPerMin, prometheus.GaugeValue, net, d.ItemName)
+		ch <- prometheus.MustNewConstMetric(ItemDeficit, prometheus.GaugeValue, parseBool(net < 0), d.ItemName)
+		if net < 0 && inventory != nil {
+			ch <- prometheus.MustNewConstMetric(ItemStockDepletion, prometheus.GaugeValue, stock[d.ItemName]/-net*60, d.ItemName)
+		}
+	}
+
+	for item, target := range c.targets {
+		ch <- prometheus.MustNewConstMetric(ItemTargetPerMin, prometheus.GaugeValue, target, item)
+		ch <- prometheus.MustNewConstMetric(ItemTargetMet, prometheus.GaugeValue, parseBool(produced[item] >= target), item)
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package exporter
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	ItemNetPerMin = RegisterNewGaugeVec(prometheus.GaugeOpts{
+		Name: "item_net_per_min",
+		Help: "Production minus consumption of an item, per minute",
+	}, []string{
+		"item_name",
+	})
+
+	ItemDeficit = RegisterNewGaugeVec(prometheus.GaugeOpts{
+		Name: "item_deficit",
+		Help: "Is more of an item consumed than produced",
+	}, []string{
+		"item_name",
+	})
+
+	ItemStockDepletion = RegisterNewGaugeVec(prometheus.GaugeOpts{
+		Name: "item_stock_depletion_seconds",
+		Help: "Estimated time until the world inventory of an item in deficit runs out, in seconds",
+	}, []string{
+		"item_name",
+	})
+
+	ItemTargetPerMin = RegisterNewGaugeVec(prometheus.GaugeOpts{
+		Name: "item_target_per_min",
+		Help: "Target production of a critical item, per minute",
+	}, []string{
+		"item_name",
+	})
+
+	ItemTargetMet = RegisterNewGaugeVec(prometheus.GaugeOpts{
+		Name: "ficsit_item_target_met",
+		Help: "Is the production of a critical item at or above its target",
+	}, []string{
+		"item_name",
+	})
+)
@@ -0,0 +1,206 @@
+package exporter
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Kinds of circuit changes.
+const (
+	CircuitMerge = "merge"
+	CircuitSplit = "split"
+)
+
+// CircuitTracker names the power circuits and follows their topology across
+// polls.
+//
+// FRM identifies circuits by a number that changes when circuits are merged
+// or split. The tracker records which circuit each factory building belongs
+// to: a circuit whose buildings come from several previous circuits, one of
+// which disappeared, is a merge. A previous circuit whose buildings are now
+// spread over several circuits, one of which is new, is a split. A new circuit
+// without a configured name inherits the name of the previous circuit most of
+// its buildings come from, so that names survive merges and splits.
+type CircuitTracker struct {
+	mu         sync.Mutex
+	configured map[float64]string
+	names      map[float64]string
+	members    map[string]float64 // building id to circuit id
+	changes    map[string]float64
+	lastChange map[float64]circuitChange
+}
+
+type circuitChange struct {
+	kind     string
+	circuits []float64 // circuits merged into the circuit, or the circuit was split from
+}
+
+// NewCircuitTracker creates a CircuitTracker, names are the configured names
+// of the circuits.
+func NewCircuitTracker(names map[float64]string) *CircuitTracker {
+	configured := map[float64]string{}
+	for id, name := range names {
+		configured[id] = name
+	}
+	return &CircuitTracker{
+		configured: configured,
+		names:      map[float64]string{},
+		changes:    map[string]float64{},
+		lastChange: map[float64]circuitChange{},
+	}
+}
+
+// Name returns the name of a circuit, its id when it has none.
+func (t *CircuitTracker) Name(circuitId float64) string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.name(circuitId)
+}
+
+func (t *CircuitTracker) name(circuitId float64) string {
+	if name, ok := t.configured[circuitId]; ok {
+		return name
+	}
+	if name, ok := t.names[circuitId]; ok {
+		return name
+	}
+	return strconv.FormatFloat(circuitId, 'f', -1, 64)
+}
+
+// labels returns the circuit_id and circuit_name labels of a circuit.
+func (t *CircuitTracker) labels(circuitId float64) []string {
+	return []string{strconv.FormatFloat(circuitId, 'f', -1, 64), t.Name(circuitId)}
+}
+
+// Observe records the circuit of each building, by building id. Buildings
+// without an id cannot be followed across observations and are skipped.
+func (t *CircuitTracker) Observe(members map[string]float64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(members, "")
+
+	if t.members != nil {
+		existed := map[float64]bool{}
+		for _, circuitId := range t.members {
+			existed[circuitId] = true
+		}
+		exists := map[float64]bool{}
+		for _, circuitId := range members {
+			exists[circuitId] = true
+		}
+
+		// Number of buildings moving from a previous circuit to a current one.
+		moves := map[float64]map[float64]int{}
+		spread := map[float64][]float64{}
+		for building, current := range members {
+			previous, ok := t.members[building]
+			if !ok {
+				continue
+			}
+			if moves[current] == nil {
+				moves[current] = map[float64]int{}
+			}
+			if moves[current][previous] == 0 {
+				spread[previous] = append(spread[previous], current)
+			}
+			moves[current][previous]++
+		}
+
+		// A single building rewired between two existing circuits is neither
+		// a merge nor a split: a merge makes a circuit disappear, a split
+		// makes one appear.
+		names := map[float64]string{}
+		for current, from := range moves {
+			best, bestCount := 0.0, 0
+			merged := false
+			for previous, count := range from {
+				if count > bestCount || (count == bestCount && previous < best) {
+					best, bestCount = previous, count
+				}
+				if previous != current && !exists[previous] {
+					merged = true
+				}
+			}
+			if !existed[current] {
+				names[current] = t.name(best)
+			}
+			if len(from) > 1 && merged {
+				t.changes[CircuitMerge]++
+				t.lastChange[current] = circuitChange{kind: CircuitMerge, circuits: keys(from)}
+			}
+		}
+		for previous, currents := range spread {
+			split := false
+			for _, current := range currents {
+				if !existed[current] {
+					split = true
+				}
+			}
+			if len(currents) > 1 && split {
+				t.changes[CircuitSplit]++
+				for _, current := range currents {
+					t.lastChange[current] = circuitChange{kind: CircuitSplit, circuits: []float64{previous}}
+				}
+			}
+		}
+
+		for id, name := range names {
+			if _, ok := t.configured[id]; !ok && name != strconv.FormatFloat(id, 'f', -1, 64) {
+				t.names[id] = name
+			}
+		}
+	}
+
+	t.members = members
+	seen := map[float64]bool{}
+	for _, circuitId := range members {
+		seen[circuitId] = true
+	}
+	for circuitId := range t.names {
+		if !seen[circuitId] {
+			delete(t.names, circuitId)
+		}
+	}
+	for circuitId := range t.lastChange {
+		if !seen[circuitId] {
+			delete(t.lastChange, circuitId)
+		}
+	}
+}
+
+func keys(m map[float64]int) []float64 {
+	result := []float64{}
+	for k := range m {
+		result = append(result, k)
+	}
+	sort.Float64s(result)
+	return result
+}
+
+func (t *CircuitTracker) collect(ch chan<- prometheus.Metric) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	buildings := map[float64]float64{}
+	for _, circuitId := range t.members {
+		buildings[circuitId]++
+	}
+	for circuitId, count := range buildings {
+		ch <- prometheus.MustNewConstMetric(CircuitBuildings, prometheus.GaugeValue, count, strconv.FormatFloat(circuitId, 'f', -1, 64), t.name(circuitId))
+	}
+	for circuitId, change := range t.lastChange {
+		circuits := []string{}
+		for _, c := range change.circuits {
+			circuits = append(circuits, strconv.FormatFloat(c, 'f', -1, 64))
+		}
+		ch <- prometheus.MustNewConstMetric(CircuitChangeInfo, prometheus.GaugeValue, 1, strconv.FormatFloat(circuitId, 'f', -1, 64), t.name(circuitId), change.kind, strings.Join(circuits, ","))
+	}
+	for _, kind := range []string{CircuitMerge, CircuitSplit} {
+		ch <- prometheus.MustNewConstMetric(CircuitChanges, prometheus.CounterValue, t.changes[kind], kind)
+	}
+}
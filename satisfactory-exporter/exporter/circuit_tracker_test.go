@@ -0,0 +1,115 @@
+package exporter
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCircuitTrackerObserve(t *testing.T) {
+	tests := []struct {
+		name         string
+		observations []map[string]float64
+		merges       float64
+		splits       float64
+		lastChange   map[float64]circuitChange
+		names        map[float64]string
+	}{{
+		name: "first observation",
+		observations: []map[string]float64{
+			{"a": 1, "b": 2},
+		},
+		lastChange: map[float64]circuitChange{},
+		names:      map[float64]string{1: "Main", 2: "2"},
+	}, {
+		name: "merge into a new circuit",
+		observations: []map[string]float64{
+			{"a": 1, "b": 1, "c": 2, "d": 2},
+			{"a": 3, "b": 3, "c": 3, "d": 3},
+		},
+		merges: 1,
+		lastChange: map[float64]circuitChange{
+			3: {kind: CircuitMerge, circuits: []float64{1, 2}},
+		},
+		names: map[float64]string{3: "Main"},
+	}, {
+		name: "merge into an existing circuit",
+		observations: []map[string]float64{
+			{"a": 2, "b": 2, "c": 1},
+			{"a": 2, "b": 2, "c": 2},
+		},
+		merges: 1,
+		lastChange: map[float64]circuitChange{
+			2: {kind: CircuitMerge, circuits: []float64{1, 2}},
+		},
+		names: map[float64]string{2: "2"},
+	}, {
+		name: "split",
+		observations: []map[string]float64{
+			{"a": 1, "b": 1, "c": 1, "d": 1},
+			{"a": 1, "b": 1, "c": 4, "d": 4},
+		},
+		splits: 1,
+		lastChange: map[float64]circuitChange{
+			1: {kind: CircuitSplit, circuits: []float64{1}},
+			4: {kind: CircuitSplit, circuits: []float64{1}},
+		},
+		names: map[float64]string{1: "Main", 4: "Main"},
+	}, {
+		name: "building rewired between existing circuits",
+		observations: []map[string]float64{
+			{"a": 1, "b": 1, "c": 2},
+			{"a": 1, "b": 2, "c": 2},
+		},
+		lastChange: map[float64]circuitChange{},
+		names:      map[float64]string{1: "Main", 2: "2"},
+	}, {
+		name: "merge then split",
+		observations: []map[string]float64{
+			{"a": 1, "b": 2},
+			{"a": 3, "b": 3},
+			{"a": 3, "b": 5},
+		},
+		merges: 1,
+		splits: 1,
+		lastChange: map[float64]circuitChange{
+			3: {kind: CircuitSplit, circuits: []float64{3}},
+			5: {kind: CircuitSplit, circuits: []float64{3}},
+		},
+		names: map[float64]string{3: "Main", 5: "Main"},
+	}, {
+		name: "buildings without an id",
+		observations: []map[string]float64{
+			{"": 1, "a": 2},
+			{"": 3, "a": 2},
+		},
+		lastChange: map[float64]circuitChange{},
+		names:      map[float64]string{2: "2"},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tracker := NewCircuitTracker(map[float64]string{1: "Main"})
+			for _, members := range test.observations {
+				tracker.Observe(members)
+			}
+
+			if merges := tracker.changes[CircuitMerge]; merges != test.merges {
+				t.Errorf("%v merges, want %v", merges, test.merges)
+			}
+			if splits := tracker.changes[CircuitSplit]; splits != test.splits {
+				t.Errorf("%v splits, want %v", splits, test.splits)
+			}
+			if !reflect.DeepEqual(tracker.lastChange, test.lastChange) {
+				t.Errorf("last changes %v, want %v", tracker.lastChange, test.lastChange)
+			}
+			for circuitId, want := range test.names {
+				if name := tracker.Name(circuitId); name != want {
+					t.Errorf("circuit %v is named %q, want %q", circuitId, name, want)
+				}
+			}
+			if _, ok := tracker.members[""]; ok {
+				t.Error("a building without an id is tracked")
+			}
+		})
+	}
+}
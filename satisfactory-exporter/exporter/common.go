@@ -1,54 +1,89 @@
 package exporter
 
 import (
-	"encoding/json"
-	"log"
-	"net/http"
+	"context"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 )
 
-var timeRegex = regexp.MustCompile(`\d\d:\d\d:\d\d`)
+// Clock formatted durations: [days(.|:|d )]hours:minutes:seconds[.fraction],
+// or minutes:seconds.
+var clockRegex = regexp.MustCompile(`^(?:(\d+)(?:[.:]|d\s*|\s+days?\s+))?(?:(\d+):)?(\d+):(\d+(?:\.\d+)?)$`)
 
-func parseTimeSeconds(timeStr string) *float64 {
-	match := timeRegex.FindStringSubmatch(timeStr)
-	if len(match) < 1 {
-		return nil
-	}
-	parts := strings.Split(match[0], ":")
-	duration := parts[0] + "h" + parts[1] + "m" + parts[2] + "s"
-	t, _ := time.ParseDuration(duration)
-	seconds := t.Seconds()
-	return &seconds
+// Source gives the collectors access to the data of a FRM route, either
+// straight from the webserver with a frm.Client or from a snapshot kept by a
+// Poller.
+type Source interface {
+	Get(ctx context.Context, route string, details any) error
 }
 
-func parseBool(b bool) float64 {
-	if b {
-		return 1
-	} else {
-		return 0
-	}
+// SnapshotSource is a Source serving snapshots of the routes. GetSnapshot also
+// returns when the snapshot was fetched from the webserver.
+type SnapshotSource interface {
+	Source
+	GetSnapshot(ctx context.Context, route string, details any) (time.Time, error)
 }
 
-func retrieveData(frmAddress string, details any) error {
-	resp, err := http.Get(frmAddress)
-
-	if err != nil {
-		log.Printf("error fetching statistics from FRM: %s\n", err)
-		return err
+// getObserved decodes a route from source and returns when its data was
+// fetched from the webserver, to timestamp the observations of the trackers:
+// scrapes served by the same snapshot share its time.
+func getObserved(ctx context.Context, source Source, route string, details any) (time.Time, error) {
+	if s, ok := source.(SnapshotSource); ok {
+		return s.GetSnapshot(ctx, route, details)
 	}
+	now := time.Now()
+	return now, source.Get(ctx, route, details)
+}
 
-	defer resp.Body.Close()
+// observedSource is a Source recording when the payload it last decoded was
+// fetched, see getObserved.
+type observedSource struct {
+	Source
+	observed time.Time
+}
 
-	decoder := json.NewDecoder(resp.Body)
-	err = decoder.Decode(&details)
+func (s *observedSource) Get(ctx context.Context, route string, details any) error {
+	observed, err := getObserved(ctx, s.Source, route, details)
+	s.observed = observed
+	return err
+}
 
-	// Try to found if it is an empty object
-	if _, ok := err.(*json.UnmarshalTypeError); ok {
-		details = []any{}
-		return nil
+// parseDurationSeconds reads a duration reported by FRM, in seconds. Clock
+// formats of any length (01:02:03, 123:04:05, 1.02:03:04.000, 2d 01:02:03,
+// 04:05), Go durations (1h2m3s) and plain seconds are accepted.
+func parseDurationSeconds(s string) (float64, bool) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "+")
+	if s == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.ParseFloat(s, 64); err == nil {
+		return seconds, seconds >= 0
+	}
+	if d, err := time.ParseDuration(s); err == nil {
+		return d.Seconds(), d >= 0
 	}
 
-	return err
+	match := clockRegex.FindStringSubmatch(s)
+	if match == nil {
+		return 0, false
+	}
+	days, _ := strconv.ParseFloat(match[1], 64)
+	hours, _ := strconv.ParseFloat(match[2], 64)
+	minutes, _ := strconv.ParseFloat(match[3], 64)
+	seconds, _ := strconv.ParseFloat(match[4], 64)
+	if match[2] == "" && match[1] != "" {
+		// days:minutes:seconds is not a format, the first field is hours.
+		hours, days = days, 0
+	}
+	return ((days*24+hours)*60+minutes)*60 + seconds, true
+}
+
+func parseBool(b bool) float64 {
+	if b {
+		return 1
+	} else {
+		return 0
+	}
 }
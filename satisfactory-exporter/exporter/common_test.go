@@ -0,0 +1,25 @@
+package exporter
+
+import "testing"
+
+func TestParseDurationSeconds(t *testing.T) {
+	tests := []struct {
+		input   string
+		seconds float64
+		ok      bool
+	}{
+		{input: "01:02:03", seconds: 3723, ok: true},
+		{input: "1.02:03:04.000", seconds: 93784, ok: true},
+		{input: "2d 01:02:03", seconds: 176523, ok: true},
+		{input: "1h2m3s", seconds: 3723, ok: true},
+		{input: "42", seconds: 42, ok: true},
+		{input: "", ok: false},
+		{input: "abc", ok: false},
+	}
+	for _, test := range tests {
+		seconds, ok := parseDurationSeconds(test.input)
+		if ok != test.ok || (ok && seconds != test.seconds) {
+			t.Errorf("parseDurationSeconds(%q) = %v, %v, want %v, %v", test.input, seconds, ok, test.seconds, test.ok)
+		}
+	}
+}
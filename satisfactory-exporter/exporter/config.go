@@ -0,0 +1,109 @@
+package exporter
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Config is the content of the configuration file of the exporter.
+type Config struct {
+	Servers []ServerConfig `yaml:"servers"`
+	// Names of the power circuits of the server of -frm.listen-address.
+	Circuits      map[string]string `yaml:"circuits"`
+	Labels        []LabelRule       `yaml:"labels"`
+	CriticalItems []CriticalItem    `yaml:"critical_items"`
+}
+
+// ServerConfig describes a FRM webserver monitored by the exporter.
+type ServerConfig struct {
+	Name    string `yaml:"name"`
+	Address string `yaml:"address"`
+	// Names of the power circuits, by circuit id.
+	Circuits map[string]string `yaml:"circuits"`
+}
+
+// parseCircuitNames reads the names of the power circuits, by circuit id.
+func parseCircuitNames(names map[string]string) (map[float64]string, error) {
+	circuits := map[float64]string{}
+	for id, name := range names {
+		circuitId, err := strconv.ParseFloat(id, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid circuit id %q", id)
+		}
+		circuits[circuitId] = name
+	}
+	return circuits, nil
+}
+
+// CircuitNames returns the names of the power circuits of the server of
+// -frm.listen-address.
+func (c *Config) CircuitNames() map[float64]string {
+	names, _ := parseCircuitNames(c.Circuits)
+	return names
+}
+
+// CircuitNames returns the names of the power circuits of the server.
+func (s *ServerConfig) CircuitNames() map[float64]string {
+	names, _ := parseCircuitNames(s.Circuits)
+	return names
+}
+
+// CriticalItem is an item whose production is checked by the balance
+// collector.
+type CriticalItem struct {
+	Name         string  `yaml:"name"`
+	TargetPerMin float64 `yaml:"target_per_min"`
+}
+
+// Targets returns the target production per minute of the critical items.
+func (c *Config) Targets() map[string]float64 {
+	targets := map[string]float64{}
+	for _, item := range c.CriticalItems {
+		targets[item.Name] = item.TargetPerMin
+	}
+	return targets
+}
+
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	config := &Config{}
+	if err := yaml.UnmarshalStrict(data, config); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+
+	names := map[string]bool{}
+	for _, s := range config.Servers {
+		if s.Name == "" || s.Address == "" {
+			return nil, fmt.Errorf("parsing %s: servers need a name and an address", path)
+		}
+		if names[s.Name] {
+			return nil, fmt.Errorf("parsing %s: duplicate server %q", path, s.Name)
+		}
+		names[s.Name] = true
+		if _, err := parseCircuitNames(s.Circuits); err != nil {
+			return nil, fmt.Errorf("parsing %s: server %s: %w", path, s.Name, err)
+		}
+	}
+	if _, err := parseCircuitNames(config.Circuits); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+
+	items := map[string]bool{}
+	for _, item := range config.CriticalItems {
+		if item.Name == "" || item.TargetPerMin <= 0 {
+			return nil, fmt.Errorf("parsing %s: critical items need a name and a positive target_per_min", path)
+		}
+		if items[item.Name] {
+			return nil, fmt.Errorf("parsing %s: duplicate critical item %q", path, item.Name)
+		}
+		items[item.Name] = true
+	}
+	return config, nil
+}
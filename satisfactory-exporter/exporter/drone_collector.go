@@ -0,0 +1,50 @@
+package exporter
+
+import (
+	"context"
+
+	"github.com/go-kit/log"
+	"github.com/justereseau/satisfactory-metrics/satisfactory-exporter/frm"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type DroneCollector struct {
+	source Source
+	logger log.Logger
+}
+
+func NewDroneCollector(source Source, logger log.Logger) *DroneCollector {
+	return &DroneCollector{
+		source: source,
+		logger: logger,
+	}
+}
+
+func (c *DroneCollector) Update(ctx context.Context, ch chan<- prometheus.Metric) error {
+	details, err := frm.Routes{Getter: c.source}.GetDrone(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, d := range details {
+		id := d.Id
+		home := d.HomeStation
+
+		ch <- prometheus.MustNewConstMetric(DroneInfo, prometheus.GaugeValue, 1, id, home, d.PairedStation, d.CurrentDestination)
+		ch <- prometheus.MustNewConstMetric(DroneSpeed, prometheus.GaugeValue, d.FlyingSpeed, id, home)
+
+		p := project(d.Location)
+		ch <- prometheus.MustNewConstMetric(DronePosition, prometheus.GaugeValue, p.X, id, home, "X")
+		ch <- prometheus.MustNewConstMetric(DronePosition, prometheus.GaugeValue, p.Y, id, home, "Y")
+		ch <- prometheus.MustNewConstMetric(DronePosition, prometheus.GaugeValue, d.Location.Z, id, home, "Z")
+
+		batteries := 0.0
+		for _, f := range d.Fuel {
+			batteries += f.Amount
+		}
+		ch <- prometheus.MustNewConstMetric(DroneBatteries, prometheus.GaugeValue, batteries, id, home)
+
+		DroneFlyingState.collect(ch, c.logger, d.CurrentFlyingMode, id, home)
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package exporter
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	DroneInfo = RegisterNewGaugeVec(prometheus.GaugeOpts{
+		Name: "drone_info",
+		Help: "Drone ports of a drone and its current destination, always 1",
+	}, []string{
+		"id",
+		"home_station",
+		"paired_station",
+		"destination",
+	})
+	DroneFlyingState = RegisterNewStateSet(prometheus.GaugeOpts{
+		Name: "drone_flying_state",
+		Help: "Flying state of a drone, 1 for the current state and 0 for the others",
+	}, []string{
+		"id",
+		"home_station",
+	}, "Idle", "Takeoff", "Flying", "Docking")
+	DroneSpeed = RegisterNewGaugeVec(prometheus.GaugeOpts{
+		Name: "drone_speed",
+		Help: "Current flying speed of a drone in km/h",
+	}, []string{
+		"id",
+		"home_station",
+	})
+	DronePosition = RegisterNewGaugeVec(prometheus.GaugeOpts{
+		Name: "drone_current_position",
+		Help: "The current position of a drone, per axis",
+	}, []string{
+		"id",
+		"home_station",
+		"axis_name",
+	})
+	DroneBatteries = RegisterNewGaugeVec(prometheus.GaugeOpts{
+		Name: "drone_batteries",
+		Help: "Number of batteries carried by a drone",
+	}, []string{
+		"id",
+		"home_station",
+	})
+)
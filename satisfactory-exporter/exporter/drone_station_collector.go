@@ -1,68 +1,39 @@
 package exporter
 
 import (
-	"strconv"
+	"context"
 
 	"github.com/go-kit/log"
-	"github.com/go-kit/log/level"
+	"github.com/justereseau/satisfactory-metrics/satisfactory-exporter/frm"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
 type DroneStationCollector struct {
-	frmTarget string
-	logger    log.Logger
+	source   Source
+	circuits *CircuitTracker
+	logger   log.Logger
 }
 
-type DroneStationDetails struct {
-	Id                     string    `json:"ID"`
-	HomeStation            string    `json:"Name"`
-	PairedStation          string    `json:"PairedStation"`
-	DroneStatus            string    `json:"DroneStatus"`
-	AvgIncRate             float64   `json:"AvgIncRate"`
-	AvgIncStack            float64   `json:"AvgIncStack"`
-	AvgOutRate             float64   `json:"AvgOutRate"`
-	AvgOutStack            float64   `json:"AvgOutStack"`
-	AvgRndTrip             string    `json:"AvgRndTrip"`
-	AvgTotalIncRate        float64   `json:"AvgTotalIncRate"`
-	AvgTotalIncStack       float64   `json:"AvgTotalIncStack"`
-	AvgTotalOutRate        float64   `json:"AvgTotalOutRate"`
-	AvgTotalOutStack       float64   `json:"AvgTotalOutStack"`
-	AvgTripIncAmt          float64   `json:"AvgTripIncAmt"`
-	EstRndTrip             string    `json:"EstRndTrip"`
-	EstTotalTransRate      float64   `json:"EstTotalTransRate"`
-	EstTransRate           float64   `json:"EstTransRate"`
-	EstLatestTotalIncStack float64   `json:"EstLatestTotalIncStack"`
-	EstLatestTotalOutStack float64   `json:"EstLatestTotalOutStack"`
-	LatestIncStack         float64   `json:"LatestIncStack"`
-	LatestOutStack         float64   `json:"LatestOutStack"`
-	LatestRndTrip          string    `json:"LatestRndTrip"`
-	LatestTripIncAmt       float64   `json:"LatestTripIncAmt"`
-	LatestTripOutAmt       float64   `json:"LatestTripOutAmt"`
-	MedianRndTrip          string    `json:"MedianRndTrip"`
-	MedianTripIncAmt       float64   `json:"MedianTripIncAmt"`
-	MedianTripOutAmt       float64   `json:"MedianTripOutAmt"`
-	EstBatteryRate         float64   `json:"EstBatteryRate"`
-	PowerInfo              PowerInfo `json:"PowerInfo"`
-}
-
-func NewDroneStationCollector(frmApiAddress string, logger log.Logger) *DroneStationCollector {
+func NewDroneStationCollector(source Source, circuits *CircuitTracker, logger log.Logger) *DroneStationCollector {
 	return &DroneStationCollector{
-		frmTarget: frmApiAddress + "/getDroneStation",
-		logger:    logger,
+		source:   source,
+		circuits: circuits,
+		logger:   logger,
 	}
 }
 
-func (c DroneStationCollector) Describe(ch chan<- *prometheus.Desc) {}
-
-func (c *DroneStationCollector) Collect(ch chan<- prometheus.Metric) {
-	details := []DroneStationDetails{}
-	err := retrieveData(c.frmTarget, &details)
+func (c *DroneStationCollector) Update(ctx context.Context, ch chan<- prometheus.Metric) error {
+	details, err := frm.Routes{Getter: c.source}.GetDroneStation(ctx)
 	if err != nil {
-		level.Error(c.logger).Log("msg", "Error reading drone statistics from Ficsit Metrics", "err", err)
-		return
+		return err
 	}
 
+	portPower, _ := Game.Power("Drone Port")
 	powerInfo := map[float64]float64{}
+	maxPowerInfo := map[float64]float64{}
+	// Items and batteries per minute of each home and paired port pair.
+	routeItems := map[[2]string]float64{}
+	routeBatteries := map[[2]string]float64{}
 	for _, d := range details {
 		id := d.Id
 		home := d.HomeStation
@@ -70,9 +41,30 @@ func (c *DroneStationCollector) Collect(ch chan<- prometheus.Metric) {
 
 		ch <- prometheus.MustNewConstMetric(DronePortBatteryRate, prometheus.GaugeValue, d.EstBatteryRate, id, home, paired)
 
-		roundTrip := parseTimeSeconds(d.LatestRndTrip)
-		if roundTrip != nil {
-			ch <- prometheus.MustNewConstMetric(DronePortRndTrip, prometheus.GaugeValue, *roundTrip, id, home, paired)
+		ch <- prometheus.MustNewConstMetric(DronePortIncomingRate, prometheus.GaugeValue, d.AvgIncRate, id, home, paired)
+		ch <- prometheus.MustNewConstMetric(DronePortOutgoingRate, prometheus.GaugeValue, d.AvgOutRate, id, home, paired)
+		ch <- prometheus.MustNewConstMetric(DronePortEstimatedRate, prometheus.GaugeValue, d.EstTransRate, id, home, paired)
+		ch <- prometheus.MustNewConstMetric(DronePortIncomingStacks, prometheus.GaugeValue, d.AvgIncStack, id, home, paired)
+		ch <- prometheus.MustNewConstMetric(DronePortOutgoingStacks, prometheus.GaugeValue, d.AvgOutStack, id, home, paired)
+		ch <- prometheus.MustNewConstMetric(DronePortLatestIncomingStacks, prometheus.GaugeValue, d.LatestIncStack, id, home, paired)
+		ch <- prometheus.MustNewConstMetric(DronePortLatestOutgoingStacks, prometheus.GaugeValue, d.LatestOutStack, id, home, paired)
+
+		if roundTrip, ok := parseDurationSeconds(d.LatestRndTrip); ok {
+			ch <- prometheus.MustNewConstMetric(DronePortRndTrip, prometheus.GaugeValue, roundTrip, id, home, paired)
+		}
+		if roundTrip, ok := parseDurationSeconds(d.EstRndTrip); ok {
+			ch <- prometheus.MustNewConstMetric(DronePortEstRndTrip, prometheus.GaugeValue, roundTrip, id, home, paired)
+		}
+		if roundTrip, ok := parseDurationSeconds(d.MedianRndTrip); ok {
+			ch <- prometheus.MustNewConstMetric(DronePortMedianRndTrip, prometheus.GaugeValue, roundTrip, id, home, paired)
+		}
+
+		DronePortStatus.collect(ch, c.logger, d.DroneStatus, id, home, paired)
+
+		if paired != "" {
+			route := [2]string{home, paired}
+			routeItems[route] += d.EstTransRate
+			routeBatteries[route] += d.EstBatteryRate
 		}
 
 		val, ok := powerInfo[d.PowerInfo.CircuitId]
@@ -81,9 +73,20 @@ func (c *DroneStationCollector) Collect(ch chan<- prometheus.Metric) {
 		} else {
 			powerInfo[d.PowerInfo.CircuitId] = d.PowerInfo.PowerConsumed
 		}
+		maxPowerInfo[d.PowerInfo.CircuitId] += portPower
+	}
+
+	for route, batteries := range routeBatteries {
+		if batteries > 0 {
+			ch <- prometheus.MustNewConstMetric(DroneRouteItemsPerBattery, prometheus.GaugeValue, routeItems[route]/batteries, route[0], route[1])
+		}
 	}
 
 	for circuitId, powerConsumed := range powerInfo {
-		ch <- prometheus.MustNewConstMetric(DronePortPower, prometheus.GaugeValue, powerConsumed, strconv.FormatFloat(circuitId, 'f', -1, 64))
+		ch <- prometheus.MustNewConstMetric(DronePortPower, prometheus.GaugeValue, powerConsumed, c.circuits.labels(circuitId)...)
+	}
+	for circuitId, powerConsumed := range maxPowerInfo {
+		ch <- prometheus.MustNewConstMetric(DronePortPowerMax, prometheus.GaugeValue, powerConsumed, c.circuits.labels(circuitId)...)
 	}
+	return nil
 }
@@ -0,0 +1,95 @@
+package exporter
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	DronePortIncomingRate = RegisterNewGaugeVec(prometheus.GaugeOpts{
+		Name: "drone_port_incoming_items_per_min",
+		Help: "Average number of items per minute delivered to a drone port",
+	}, []string{
+		"id",
+		"home_station",
+		"paired_station",
+	})
+	DronePortOutgoingRate = RegisterNewGaugeVec(prometheus.GaugeOpts{
+		Name: "drone_port_outgoing_items_per_min",
+		Help: "Average number of items per minute sent from a drone port",
+	}, []string{
+		"id",
+		"home_station",
+		"paired_station",
+	})
+	DronePortEstimatedRate = RegisterNewGaugeVec(prometheus.GaugeOpts{
+		Name: "drone_port_estimated_items_per_min",
+		Help: "Estimated number of items per minute transported by the drone of a port",
+	}, []string{
+		"id",
+		"home_station",
+		"paired_station",
+	})
+	DronePortIncomingStacks = RegisterNewGaugeVec(prometheus.GaugeOpts{
+		Name: "drone_port_incoming_stacks_per_min",
+		Help: "Average number of stacks per minute delivered to a drone port",
+	}, []string{
+		"id",
+		"home_station",
+		"paired_station",
+	})
+	DronePortOutgoingStacks = RegisterNewGaugeVec(prometheus.GaugeOpts{
+		Name: "drone_port_outgoing_stacks_per_min",
+		Help: "Average number of stacks per minute sent from a drone port",
+	}, []string{
+		"id",
+		"home_station",
+		"paired_station",
+	})
+	DronePortLatestIncomingStacks = RegisterNewGaugeVec(prometheus.GaugeOpts{
+		Name: "drone_port_latest_incoming_stacks",
+		Help: "Number of stacks delivered to a drone port by the latest trip",
+	}, []string{
+		"id",
+		"home_station",
+		"paired_station",
+	})
+	DronePortLatestOutgoingStacks = RegisterNewGaugeVec(prometheus.GaugeOpts{
+		Name: "drone_port_latest_outgoing_stacks",
+		Help: "Number of stacks sent from a drone port by the latest trip",
+	}, []string{
+		"id",
+		"home_station",
+		"paired_station",
+	})
+	DronePortEstRndTrip = RegisterNewGaugeVec(prometheus.GaugeOpts{
+		Name: "drone_port_round_trip_estimated_seconds",
+		Help: "Estimated drone round trip time in seconds",
+	}, []string{
+		"id",
+		"home_station",
+		"paired_station",
+	})
+	DronePortMedianRndTrip = RegisterNewGaugeVec(prometheus.GaugeOpts{
+		Name: "drone_port_round_trip_median_seconds",
+		Help: "Median of the recorded drone round trip times in seconds",
+	}, []string{
+		"id",
+		"home_station",
+		"paired_station",
+	})
+	DronePortStatus = RegisterNewStateSet(prometheus.GaugeOpts{
+		Name: "drone_port_status",
+		Help: "Status of the drone of a drone port, 1 for the current status and 0 for the others",
+	}, []string{
+		"id",
+		"home_station",
+		"paired_station",
+	}, "No Drone", "Docked", "Loading", "Unloading", "Takeoff", "Docking", "En Route", "Not Enough Batteries", "Cannot Unload")
+	DroneRouteItemsPerBattery = RegisterNewGaugeVec(prometheus.GaugeOpts{
+		Name: "drone_route_items_per_battery",
+		Help: "Estimated number of items transported per battery consumed between a home and a paired drone port",
+	}, []string{
+		"home_station",
+		"paired_station",
+	})
+)
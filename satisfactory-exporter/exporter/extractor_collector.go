@@ -0,0 +1,72 @@
+package exporter
+
+import (
+	"context"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/justereseau/satisfactory-metrics/satisfactory-exporter/frm"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Output multiplier of a resource node, per purity.
+var purityMultiplier = map[string]float64{
+	"Impure": 0.5,
+	"Normal": 1,
+	"Pure":   2,
+}
+
+type ExtractorCollector struct {
+	source   Source
+	circuits *CircuitTracker
+	logger   log.Logger
+}
+
+func NewExtractorCollector(source Source, circuits *CircuitTracker, logger log.Logger) *ExtractorCollector {
+	return &ExtractorCollector{
+		source:   source,
+		circuits: circuits,
+		logger:   logger,
+	}
+}
+
+func (c *ExtractorCollector) Update(ctx context.Context, ch chan<- prometheus.Metric) error {
+	details, err := frm.Routes{Getter: c.source}.GetExtractor(ctx)
+	if err != nil {
+		return err
+	}
+
+	powerInfo := map[float64]float64{}
+	maxPowerInfo := map[float64]float64{}
+	for _, extractor := range details {
+		gh, xs, ys, zs := locationLabels(extractor.Location)
+
+		for _, prod := range extractor.Production {
+			ch <- prometheus.MustNewConstMetric(ExtractorItemsProducedPerMin, prometheus.GaugeValue, prod.CurrentProd, prod.Name, extractor.Id, extractor.Building, gh, xs, ys, zs)
+			ch <- prometheus.MustNewConstMetric(ExtractorItemsProducedEffiency, prometheus.GaugeValue, prod.ProdPercent, prod.Name, extractor.Id, extractor.Building, gh, xs, ys, zs)
+		}
+
+		if multiplier, ok := purityMultiplier[extractor.Purity]; ok {
+			ch <- prometheus.MustNewConstMetric(ExtractorPurity, prometheus.GaugeValue, multiplier, extractor.Id, extractor.Building, extractor.Purity, gh, xs, ys, zs)
+		}
+		ch <- prometheus.MustNewConstMetric(ExtractorClockSpeed, prometheus.GaugeValue, extractor.ManuSpeed, extractor.Id, extractor.Building, gh, xs, ys, zs)
+		ch <- prometheus.MustNewConstMetric(ExtractorProducing, prometheus.GaugeValue, parseBool(extractor.IsProducing), extractor.Id, extractor.Building, gh, xs, ys, zs)
+		ch <- prometheus.MustNewConstMetric(ExtractorPaused, prometheus.GaugeValue, parseBool(extractor.IsPaused), extractor.Id, extractor.Building, gh, xs, ys, zs)
+
+		powerInfo[extractor.PowerInfo.CircuitId] += extractor.PowerInfo.PowerConsumed
+
+		// extractors follow the same clock speed power curve as production buildings
+		maxExtractorPower, ok := Game.MaxPower(extractor.Building, extractor.ManuSpeed, 0)
+		if !ok {
+			level.Debug(c.logger).Log("msg", "Unknown extractor power", "building", extractor.Building)
+		}
+		maxPowerInfo[extractor.PowerInfo.CircuitId] += maxExtractorPower
+	}
+	for circuitId, powerConsumed := range powerInfo {
+		ch <- prometheus.MustNewConstMetric(ExtractorPower, prometheus.GaugeValue, powerConsumed, c.circuits.labels(circuitId)...)
+	}
+	for circuitId, powerConsumed := range maxPowerInfo {
+		ch <- prometheus.MustNewConstMetric(ExtractorPowerMax, prometheus.GaugeValue, powerConsumed, c.circuits.labels(circuitId)...)
+	}
+	return nil
+}
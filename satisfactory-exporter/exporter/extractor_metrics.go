@@ -0,0 +1,91 @@
+package exporter
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	ExtractorItemsProducedPerMin = RegisterNewGaugeVec(prometheus.GaugeOpts{
+		Name: "extractor_items_produced_per_min",
+		Help: "How much of a resource an extractor is producing, per minute",
+	}, []string{
+		"item_name",
+		"id",
+		"machine_name",
+		"geohash",
+		"x",
+		"y",
+		"z",
+	})
+	ExtractorItemsProducedEffiency = RegisterNewGaugeVec(prometheus.GaugeOpts{
+		Name: "extractor_items_produced_pc",
+		Help: "The productivity of an extractor, in percent of its maximum output",
+	}, []string{
+		"item_name",
+		"id",
+		"machine_name",
+		"geohash",
+		"x",
+		"y",
+		"z",
+	})
+	ExtractorPurity = RegisterNewGaugeVec(prometheus.GaugeOpts{
+		Name: "extractor_purity",
+		Help: "Output multiplier of the resource node the extractor is built on. 0.5 = Impure, 1 = Normal, 2 = Pure",
+	}, []string{
+		"id",
+		"machine_name",
+		"purity",
+		"geohash",
+		"x",
+		"y",
+		"z",
+	})
+	ExtractorClockSpeed = RegisterNewGaugeVec(prometheus.GaugeOpts{
+		Name: "extractor_clock_speed",
+		Help: "Clock speed of the extractor, in percent",
+	}, []string{
+		"id",
+		"machine_name",
+		"geohash",
+		"x",
+		"y",
+		"z",
+	})
+	ExtractorProducing = RegisterNewGaugeVec(prometheus.GaugeOpts{
+		Name: "extractor_is_producing",
+		Help: "Is the extractor producing",
+	}, []string{
+		"id",
+		"machine_name",
+		"geohash",
+		"x",
+		"y",
+		"z",
+	})
+	ExtractorPaused = RegisterNewGaugeVec(prometheus.GaugeOpts{
+		Name: "extractor_is_paused",
+		Help: "Has the extractor been paused",
+	}, []string{
+		"id",
+		"machine_name",
+		"geohash",
+		"x",
+		"y",
+		"z",
+	})
+	ExtractorPower = RegisterNewGaugeVec(prometheus.GaugeOpts{
+		Name: "extractor_power",
+		Help: "Power draw from extractors in MW",
+	}, []string{
+		"circuit_id",
+		"circuit_name",
+	})
+	ExtractorPowerMax = RegisterNewGaugeVec(prometheus.GaugeOpts{
+		Name: "extractor_power_max",
+		Help: "Max power draw from extractors in MW",
+	}, []string{
+		"circuit_id",
+		"circuit_name",
+	})
+)
@@ -1,56 +1,53 @@
 package exporter
 
 import (
-	"math"
-	"strconv"
+	"context"
 
 	"github.com/go-kit/log"
 	"github.com/go-kit/log/level"
-	"github.com/pierrre/geohash"
+	"github.com/justereseau/satisfactory-metrics/satisfactory-exporter/frm"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
 type FactoryBuildingCollector struct {
-	frmTarget string
-	logger    log.Logger
+	source   Source
+	circuits *CircuitTracker
+	logger   log.Logger
 }
 
-func NewFactoryBuildingCollector(frmApiAddress string, logger log.Logger) *FactoryBuildingCollector {
+func NewFactoryBuildingCollector(source Source, circuits *CircuitTracker, logger log.Logger) *FactoryBuildingCollector {
 	return &FactoryBuildingCollector{
-		frmTarget: frmApiAddress + "/getFactory",
-		logger:    logger,
+		source:   source,
+		circuits: circuits,
+		logger:   logger,
 	}
 }
 
-func (c FactoryBuildingCollector) Describe(ch chan<- *prometheus.Desc) {}
-
-func (c *FactoryBuildingCollector) Collect(ch chan<- prometheus.Metric) {
-	details := []BuildingDetail{}
-	err := retrieveData(c.frmTarget, &details)
+func (c *FactoryBuildingCollector) Update(ctx context.Context, ch chan<- prometheus.Metric) error {
+	details, err := frm.Routes{Getter: c.source}.GetFactory(ctx)
 	if err != nil {
-		level.Error(c.logger).Log("msg", "Error reading building statistics from Ficsit Metrics", "err", err)
-		return
+		return err
 	}
 
+	members := map[string]float64{}
 	powerInfo := map[float64]float64{}
+	minPowerInfo := map[float64]float64{}
 	maxPowerInfo := map[float64]float64{}
 	for _, building := range details {
+		members[building.Id] = building.PowerInfo.CircuitId
+		gh, xs, ys, zs := locationLabels(building.Location)
 		for _, prod := range building.Production {
-			x := building.Location.X*0.000239930467 - 79.70308527
-			y := -building.Location.Y*0.0001413589137 + 36.97720935
-			z := building.Location.Z
-			gh := geohash.EncodeAuto(y, x)
-
 			ch <- prometheus.MustNewConstMetric(
 				MachineItemsProducedPerMin,
 				prometheus.GaugeValue,
 				prod.CurrentProd,
 				prod.Name,
+				building.Id,
 				building.Building,
 				gh,
-				strconv.FormatFloat(x, 'f', -1, 64),
-				strconv.FormatFloat(y, 'f', -1, 64),
-				strconv.FormatFloat(z, 'f', -1, 64),
+				xs,
+				ys,
+				zs,
 			)
 
 			ch <- prometheus.MustNewConstMetric(
@@ -58,51 +55,59 @@ func (c *FactoryBuildingCollector) Collect(ch chan<- prometheus.Metric) {
 				prometheus.GaugeValue,
 				prod.ProdPercent,
 				prod.Name,
+				building.Id,
 				building.Building,
 				gh,
-				strconv.FormatFloat(x, 'f', -1, 64),
-				strconv.FormatFloat(y, 'f', -1, 64),
-				strconv.FormatFloat(z, 'f', -1, 64),
+				xs,
+				ys,
+				zs,
 			)
 		}
 
+		MachineStatus.collect(ch, c.logger, machineStatus(building.IsConfigured, building.IsProducing, building.IsPaused), building.Id, building.Building, gh, xs, ys, zs)
+		ch <- prometheus.MustNewConstMetric(MachinePowerShards, prometheus.GaugeValue, building.PowerShards, building.Id, building.Building, gh, xs, ys, zs)
+		ch <- prometheus.MustNewConstMetric(MachineSomersloops, prometheus.GaugeValue, building.Somersloops, building.Id, building.Building, gh, xs, ys, zs)
+		ch <- prometheus.MustNewConstMetric(MachineProductionBoost, prometheus.GaugeValue, Game.ProductionBoost(building.Building, building.Somersloops), building.Id, building.Building, gh, xs, ys, zs)
+
 		val, ok := powerInfo[building.PowerInfo.CircuitId]
 		if ok {
 			powerInfo[building.PowerInfo.CircuitId] = val + building.PowerInfo.PowerConsumed
 		} else {
 			powerInfo[building.PowerInfo.CircuitId] = building.PowerInfo.PowerConsumed
 		}
-		val, ok = maxPowerInfo[building.PowerInfo.CircuitId]
-		maxBuildingPower := 0.0
-		switch building.Building {
-		case "Smelter":
-			maxBuildingPower = SmelterPower
-		case "Constructor":
-			maxBuildingPower = ConstructorPower
-		case "Assembler":
-			maxBuildingPower = AssemblerPower
-		case "Manufacturer":
-			maxBuildingPower = ManufacturerPower
-		case "Blender":
-			maxBuildingPower = BlenderPower
-		case "Refinery":
-			maxBuildingPower = RefineryPower
-		case "Particle Accelerator":
-			maxBuildingPower = ParticleAcceleratorPower
-		}
-		//update max power from clock speed
-		// see https://satisfactory.wiki.gg/wiki/Clock_speed#Clock_speed_for_production_buildings for power info
-		maxBuildingPower = maxBuildingPower * (math.Pow(building.ManuSpeed/100, 1.321928))
-		if ok {
-			maxPowerInfo[building.PowerInfo.CircuitId] = val + maxBuildingPower
-		} else {
-			maxPowerInfo[building.PowerInfo.CircuitId] = maxBuildingPower
+		maxBuildingPower, ok := Game.MaxPower(building.Building, building.ManuSpeed, building.Somersloops)
+		if !ok {
+			level.Debug(c.logger).Log("msg", "Unknown building power", "building", building.Building)
 		}
+		maxPowerInfo[building.PowerInfo.CircuitId] += maxBuildingPower
+		minBuildingPower, _ := Game.MinPower(building.Building, building.ManuSpeed, building.Somersloops)
+		minPowerInfo[building.PowerInfo.CircuitId] += minBuildingPower
 	}
+	c.circuits.Observe(members)
+	c.circuits.collect(ch)
+
 	for circuitId, powerConsumed := range powerInfo {
-		ch <- prometheus.MustNewConstMetric(FactoryPower, prometheus.GaugeValue, powerConsumed, strconv.FormatFloat(circuitId, 'f', -1, 64))
+		ch <- prometheus.MustNewConstMetric(FactoryPower, prometheus.GaugeValue, powerConsumed, c.circuits.labels(circuitId)...)
+	}
+	for circuitId, powerConsumed := range minPowerInfo {
+		ch <- prometheus.MustNewConstMetric(FactoryPowerMin, prometheus.GaugeValue, powerConsumed, c.circuits.labels(circuitId)...)
 	}
 	for circuitId, powerConsumed := range maxPowerInfo {
-		ch <- prometheus.MustNewConstMetric(FactoryPowerMax, prometheus.GaugeValue, powerConsumed, strconv.FormatFloat(circuitId, 'f', -1, 64))
+		ch <- prometheus.MustNewConstMetric(FactoryPowerMax, prometheus.GaugeValue, powerConsumed, c.circuits.labels(circuitId)...)
+	}
+	return nil
+}
+
+// machineStatus summarizes the state of a factory building or an extractor.
+func machineStatus(isConfigured bool, isProducing bool, isPaused bool) string {
+	switch {
+	case !isConfigured:
+		return "unconfigured"
+	case isPaused:
+		return "paused"
+	case isProducing:
+		return "producing"
+	default:
+		return "idle"
 	}
 }
@@ -10,6 +10,7 @@ var (
 		Help: "How much of an item a building is producing",
 	}, []string{
 		"item_name",
+		"id",
 		"machine_name",
 		"geohash",
 		"x",
@@ -22,23 +23,81 @@ var (
 		Help: "The efficiency with which a building is producing an item",
 	}, []string{
 		"item_name",
+		"id",
 		"machine_name",
 		"geohash",
 		"x",
 		"y",
 		"z",
 	})
+	MachineStatus = RegisterNewStateSet(prometheus.GaugeOpts{
+		Name: "machine_status",
+		Help: "Status of a building, 1 for the current status and 0 for the others",
+	}, []string{
+		"id",
+		"machine_name",
+		"geohash",
+		"x",
+		"y",
+		"z",
+	}, "producing", "idle", "paused", "unconfigured")
+
+	MachinePowerShards = RegisterNewGaugeVec(prometheus.GaugeOpts{
+		Name: "machine_power_shards",
+		Help: "Number of power shards slotted in a building",
+	}, []string{
+		"id",
+		"machine_name",
+		"geohash",
+		"x",
+		"y",
+		"z",
+	})
+
+	MachineSomersloops = RegisterNewGaugeVec(prometheus.GaugeOpts{
+		Name: "machine_somersloops",
+		Help: "Number of somersloops slotted in a building",
+	}, []string{
+		"id",
+		"machine_name",
+		"geohash",
+		"x",
+		"y",
+		"z",
+	})
+
+	MachineProductionBoost = RegisterNewGaugeVec(prometheus.GaugeOpts{
+		Name: "machine_production_boost",
+		Help: "Output multiplier of a building from production amplification",
+	}, []string{
+		"id",
+		"machine_name",
+		"geohash",
+		"x",
+		"y",
+		"z",
+	})
+
 	FactoryPower = RegisterNewGaugeVec(prometheus.GaugeOpts{
 		Name: "factory_power",
-		Help: "Power draw from factory machines in MW. Does not include extractors.",
+		Help: "Power draw from factory machines in MW. Extractors are reported by extractor_power.",
 	}, []string{
 		"circuit_id",
+		"circuit_name",
 	})
 
+	FactoryPowerMin = RegisterNewGaugeVec(prometheus.GaugeOpts{
+		Name: "factory_power_min",
+		Help: "Min power draw from factory machines in MW, at the low point of the power cycle of the variable power machines, such as the Particle Accelerator. Extractors are not included.",
+	}, []string{
+		"circuit_id",
+		"circuit_name",
+	})
 	FactoryPowerMax = RegisterNewGaugeVec(prometheus.GaugeOpts{
 		Name: "factory_power_max",
-		Help: "Max power draw from factory machines in MW. Does not include extractors.",
+		Help: "Max power draw from factory machines in MW, accounting for clock speed and production amplification. Extractors are reported by extractor_power_max.",
 	}, []string{
 		"circuit_id",
+		"circuit_name",
 	})
 )
@@ -0,0 +1,129 @@
+package exporter
+
+import (
+	_ "embed"
+	"fmt"
+	"math"
+	"os"
+
+	"gopkg.in/yaml.v2"
+)
+
+//go:embed gamedata.yml
+var embeddedGameData []byte
+
+// Game is the power model used by the collectors, the embedded game data
+// unless replaced by LoadGameData.
+var Game = mustParseGameData(embeddedGameData)
+
+// GameData describes the power of the buildings, see gamedata.yml.
+type GameData struct {
+	ClockSpeedExponent      float64                 `yaml:"clock_speed_exponent"`
+	SomersloopPowerExponent float64                 `yaml:"somersloop_power_exponent"`
+	Buildings               map[string]BuildingData `yaml:"buildings"`
+}
+
+type BuildingData struct {
+	// Power in MW at 100% clock speed, the peak power of variable power
+	// buildings.
+	Power float64 `yaml:"power"`
+	// Lowest power of variable power buildings.
+	MinPower float64 `yaml:"min_power"`
+	Variable bool    `yaml:"variable"`
+	// Power when the building has nothing to do, if it differs from its
+	// reported consumption.
+	IdlePower       float64 `yaml:"idle_power"`
+	SomersloopSlots int     `yaml:"somersloop_slots"`
+}
+
+func parseGameData(data []byte) (*GameData, error) {
+	g := &GameData{}
+	if err := yaml.UnmarshalStrict(data, g); err != nil {
+		return nil, err
+	}
+	for name, b := range g.Buildings {
+		if b.Power < 0 || b.MinPower < 0 || b.IdlePower < 0 || b.SomersloopSlots < 0 {
+			return nil, fmt.Errorf("negative value for %s", name)
+		}
+		if b.Variable && b.MinPower > b.Power {
+			return nil, fmt.Errorf("min_power of %s is above its power", name)
+		}
+	}
+	return g, nil
+}
+
+func mustParseGameData(data []byte) *GameData {
+	g, err := parseGameData(data)
+	if err != nil {
+		panic(fmt.Sprintf("parsing embedded game data: %s", err))
+	}
+	return g
+}
+
+// LoadGameData replaces Game with the content of a game data file.
+func LoadGameData(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	g, err := parseGameData(data)
+	if err != nil {
+		return fmt.Errorf("parsing %s: %w", path, err)
+	}
+	Game = g
+	return nil
+}
+
+// Power returns the power of a building at 100% clock speed, and false if the
+// building is unknown.
+func (g *GameData) Power(building string) (float64, bool) {
+	b, ok := g.Buildings[building]
+	return b.Power, ok
+}
+
+// IdlePower returns the power of a building with nothing to do.
+func (g *GameData) IdlePower(building string) float64 {
+	return g.Buildings[building].IdlePower
+}
+
+// ProductionBoost returns the output multiplier of a building amplified by
+// somersloops, 1 when the building cannot be amplified.
+func (g *GameData) ProductionBoost(building string, somersloops float64) float64 {
+	b := g.Buildings[building]
+	if b.SomersloopSlots == 0 {
+		return 1
+	}
+	return 1 + somersloops/float64(b.SomersloopSlots)
+}
+
+// MaxPower returns the power of a building running at clockSpeed percent,
+// amplified by somersloops, and false if the building is unknown. For variable
+// power buildings, it is the peak of their power cycle.
+func (g *GameData) MaxPower(building string, clockSpeed float64, somersloops float64) (float64, bool) {
+	b, ok := g.Buildings[building]
+	if !ok {
+		return 0, false
+	}
+	return g.scalePower(building, b.Power, clockSpeed, somersloops), true
+}
+
+// MinPower returns the lowest power of a building running at clockSpeed
+// percent, amplified by somersloops, and false if the building is unknown. It
+// is the MaxPower of the buildings that do not have a variable power.
+func (g *GameData) MinPower(building string, clockSpeed float64, somersloops float64) (float64, bool) {
+	b, ok := g.Buildings[building]
+	if !ok {
+		return 0, false
+	}
+	if !b.Variable {
+		return g.scalePower(building, b.Power, clockSpeed, somersloops), true
+	}
+	return g.scalePower(building, b.MinPower, clockSpeed, somersloops), true
+}
+
+// scalePower applies the clock speed and the production amplification to the
+// power of a building at 100% clock speed.
+func (g *GameData) scalePower(building string, power float64, clockSpeed float64, somersloops float64) float64 {
+	power = power * math.Pow(clockSpeed/100, g.ClockSpeedExponent)
+	return power * math.Pow(g.ProductionBoost(building, somersloops), g.SomersloopPowerExponent)
+}
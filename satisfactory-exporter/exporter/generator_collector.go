@@ -0,0 +1,56 @@
+package exporter
+
+import (
+	"context"
+
+	"github.com/go-kit/log"
+	"github.com/justereseau/satisfactory-metrics/satisfactory-exporter/frm"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type GeneratorCollector struct {
+	source Source
+	logger log.Logger
+}
+
+func NewGeneratorCollector(source Source, logger log.Logger) *GeneratorCollector {
+	return &GeneratorCollector{
+		source: source,
+		logger: logger,
+	}
+}
+
+func (c *GeneratorCollector) Update(ctx context.Context, ch chan<- prometheus.Metric) error {
+	details, err := frm.Routes{Getter: c.source}.GetGenerators(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, d := range details {
+		ch <- prometheus.MustNewConstMetric(GeneratorPowerProduced, prometheus.GaugeValue, d.RegulatedDemandProd, d.Id, d.Name)
+		ch <- prometheus.MustNewConstMetric(GeneratorPowerMax, prometheus.GaugeValue, d.PowerProductionPotential, d.Id, d.Name)
+		ch <- prometheus.MustNewConstMetric(GeneratorLoad, prometheus.GaugeValue, d.LoadPercentage, d.Id, d.Name)
+		ch <- prometheus.MustNewConstMetric(GeneratorInfo, prometheus.GaugeValue, 1, d.Id, d.Name, d.CurrentFuel)
+
+		for _, f := range d.FuelInventory {
+			ch <- prometheus.MustNewConstMetric(GeneratorFuelAmount, prometheus.GaugeValue, f.Amount, d.Id, d.Name, f.Name)
+			if f.MaxAmount > 0 {
+				ch <- prometheus.MustNewConstMetric(GeneratorFuelMaxAmount, prometheus.GaugeValue, f.MaxAmount, d.Id, d.Name, f.Name)
+			}
+		}
+
+		// only coal generators and nuclear power plants need water
+		if d.SupplementalResource != "" {
+			ch <- prometheus.MustNewConstMetric(GeneratorSupplementalAmount, prometheus.GaugeValue, d.SupplementalAmount, d.Id, d.Name, d.SupplementalResource)
+			ch <- prometheus.MustNewConstMetric(GeneratorSupplementalMaxAmount, prometheus.GaugeValue, d.SupplementalMaxAmount, d.Id, d.Name, d.SupplementalResource)
+		}
+
+		for _, w := range d.WasteInventory {
+			ch <- prometheus.MustNewConstMetric(GeneratorWasteAmount, prometheus.GaugeValue, w.Amount, d.Id, d.Name, w.Name)
+			if w.MaxAmount > 0 {
+				ch <- prometheus.MustNewConstMetric(GeneratorWasteMaxAmount, prometheus.GaugeValue, w.MaxAmount, d.Id, d.Name, w.Name)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package exporter
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	GeneratorPowerProduced = RegisterNewGaugeVec(prometheus.GaugeOpts{
+		Name: "generator_power_produced",
+		Help: "Power currently produced by the generator in MW",
+	}, []string{
+		"id",
+		"generator_type",
+	})
+	GeneratorPowerMax = RegisterNewGaugeVec(prometheus.GaugeOpts{
+		Name: "generator_power_max",
+		Help: "Power the generator can produce at its current clock speed in MW",
+	}, []string{
+		"id",
+		"generator_type",
+	})
+	GeneratorLoad = RegisterNewGaugeVec(prometheus.GaugeOpts{
+		Name: "generator_load_percent",
+		Help: "Percentage of the generator capacity being used",
+	}, []string{
+		"id",
+		"generator_type",
+	})
+	GeneratorInfo = RegisterNewGaugeVec(prometheus.GaugeOpts{
+		Name: "generator_info",
+		Help: "Fuel currently burnt by the generator, always 1",
+	}, []string{
+		"id",
+		"generator_type",
+		"fuel_type",
+	})
+	GeneratorFuelAmount = RegisterNewGaugeVec(prometheus.GaugeOpts{
+		Name: "generator_fuel_amount",
+		Help: "Amount of fuel in the generator inventory. Fluids are in m³",
+	}, []string{
+		"id",
+		"generator_type",
+		"fuel_type",
+	})
+	GeneratorFuelMaxAmount = RegisterNewGaugeVec(prometheus.GaugeOpts{
+		Name: "generator_fuel_max_amount",
+		Help: "Capacity of the generator fuel inventory. Fluids are in m³",
+	}, []string{
+		"id",
+		"generator_type",
+		"fuel_type",
+	})
+	GeneratorSupplementalAmount = RegisterNewGaugeVec(prometheus.GaugeOpts{
+		Name: "generator_supplemental_amount",
+		Help: "Amount of supplemental resource (e.g. water) in the generator, in m³",
+	}, []string{
+		"id",
+		"generator_type",
+		"resource",
+	})
+	GeneratorSupplementalMaxAmount = RegisterNewGaugeVec(prometheus.GaugeOpts{
+		Name: "generator_supplemental_max_amount",
+		Help: "Capacity of the generator for its supplemental resource, in m³",
+	}, []string{
+		"id",
+		"generator_type",
+		"resource",
+	})
+	GeneratorWasteAmount = RegisterNewGaugeVec(prometheus.GaugeOpts{
+		Name: "generator_waste_amount",
+		Help: "Amount of waste (e.g. Uranium Waste) waiting in the generator output inventory",
+	}, []string{
+		"id",
+		"generator_type",
+		"item_name",
+	})
+	GeneratorWasteMaxAmount = RegisterNewGaugeVec(prometheus.GaugeOpts{
+		Name: "generator_waste_max_amount",
+		Help: "Capacity of the generator output inventory. The generator stops once it is full",
+	}, []string{
+		"id",
+		"generator_type",
+		"item_name",
+	})
+)
@@ -0,0 +1,273 @@
+package exporter
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"github.com/justereseau/satisfactory-metrics/satisfactory-exporter/frm"
+	"github.com/justereseau/satisfactory-metrics/satisfactory-exporter/projection"
+)
+
+var ErrUnknownLayer = errors.New("unknown layer")
+
+// FeatureCollection is a GeoJSON feature collection.
+type FeatureCollection struct {
+	Type     string    `json:"type"`
+	Features []Feature `json:"features"`
+}
+
+// Feature is a GeoJSON feature located by a point.
+type Feature struct {
+	Type       string         `json:"type"`
+	Geometry   Point          `json:"geometry"`
+	Properties map[string]any `json:"properties"`
+}
+
+type Point struct {
+	Type        string    `json:"type"`
+	Coordinates []float64 `json:"coordinates"`
+}
+
+func NewFeatureCollection(features []Feature) FeatureCollection {
+	return FeatureCollection{Type: "FeatureCollection", Features: features}
+}
+
+// newFeature locates the properties. GeoJSON coordinates are a longitude and
+// a latitude, so the geomap projection is used whatever MapProjection is.
+func newFeature(l frm.Location, properties map[string]any) Feature {
+	p := projection.Geomap{}.Project(l.X, l.Y, l.Z)
+	return Feature{
+		Type: "Feature",
+		Geometry: Point{
+			Type:        "Point",
+			Coordinates: []float64{p.X, p.Y, p.Z},
+		},
+		Properties: properties,
+	}
+}
+
+// geoJSONLayers builds the features of each layer from the FRM routes.
+var geoJSONLayers = map[string]func(ctx context.Context, source Source) ([]Feature, error){
+	"factory_building": factoryBuildingFeatures,
+	"extractor":        extractorFeatures,
+	"train_station":    trainStationFeatures,
+	"train":            trainFeatures,
+	"vehicle":          vehicleFeatures,
+	"player":           playerFeatures,
+	"drone_station":    droneStationFeatures,
+}
+
+// GeoJSONLayers returns the names of the available layers.
+func GeoJSONLayers() []string {
+	names := []string{}
+	for name := range geoJSONLayers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// GeoJSONLayer returns the features of a layer, or ErrUnknownLayer.
+func GeoJSONLayer(ctx context.Context, source Source, layer string) ([]Feature, error) {
+	features, ok := geoJSONLayers[layer]
+	if !ok {
+		return nil, ErrUnknownLayer
+	}
+	return features(ctx, source)
+}
+
+func productionProperties(production []frm.Production) []map[string]any {
+	items := []map[string]any{}
+	for _, prod := range production {
+		items = append(items, map[string]any{
+			"item_name":    prod.Name,
+			"current_prod": prod.CurrentProd,
+			"max_prod":     prod.MaxProd,
+			"prod_percent": prod.ProdPercent,
+		})
+	}
+	return items
+}
+
+func factoryBuildingFeatures(ctx context.Context, source Source) ([]Feature, error) {
+	details, err := frm.Routes{Getter: source}.GetFactory(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	features := []Feature{}
+	for _, d := range details {
+		ingredients := []map[string]any{}
+		for _, ingredient := range d.Ingredients {
+			ingredients = append(ingredients, map[string]any{
+				"item_name":        ingredient.Name,
+				"current_consumed": ingredient.CurrentConsumed,
+				"max_consumed":     ingredient.MaxConsumed,
+				"cons_percent":     ingredient.ConsPercent,
+			})
+		}
+		features = append(features, newFeature(d.Location, map[string]any{
+			"id":             d.Id,
+			"machine_name":   d.Building,
+			"recipe":         d.Recipe,
+			"status":         machineStatus(d.IsConfigured, d.IsProducing, d.IsPaused),
+			"clock_speed":    d.ManuSpeed,
+			"production":     productionProperties(d.Production),
+			"ingredients":    ingredients,
+			"circuit_id":     d.PowerInfo.CircuitId,
+			"power_consumed": d.PowerInfo.PowerConsumed,
+		}))
+	}
+	return features, nil
+}
+
+func extractorFeatures(ctx context.Context, source Source) ([]Feature, error) {
+	details, err := frm.Routes{Getter: source}.GetExtractor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	features := []Feature{}
+	for _, d := range details {
+		features = append(features, newFeature(d.Location, map[string]any{
+			"id":             d.Id,
+			"machine_name":   d.Building,
+			"recipe":         d.Recipe,
+			"purity":         d.Purity,
+			"status":         machineStatus(d.IsConfigured, d.IsProducing, d.IsPaused),
+			"clock_speed":    d.ManuSpeed,
+			"production":     productionProperties(d.Production),
+			"circuit_id":     d.PowerInfo.CircuitId,
+			"power_consumed": d.PowerInfo.PowerConsumed,
+		}))
+	}
+	return features, nil
+}
+
+func trainStationFeatures(ctx context.Context, source Source) ([]Feature, error) {
+	details, err := frm.Routes{Getter: source}.GetTrainStation(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	features := []Feature{}
+	for _, d := range details {
+		platforms := []map[string]any{}
+		for _, platform := range d.CargoPlatforms {
+			platforms = append(platforms, map[string]any{
+				"loading_dock":   platform.LoadingDock,
+				"loading_status": platform.LoadingStatus,
+				"loading_mode":   platform.LoadingMode,
+				"transfer_rate":  platform.TransferRate,
+			})
+		}
+		features = append(features, newFeature(d.Location, map[string]any{
+			"name":            d.Name,
+			"cargo_platforms": platforms,
+			"circuit_id":      d.PowerInfo.CircuitId,
+			"power_consumed":  d.PowerInfo.PowerConsumed,
+		}))
+	}
+	return features, nil
+}
+
+func trainFeatures(ctx context.Context, source Source) ([]Feature, error) {
+	details, err := frm.Routes{Getter: source}.GetTrains(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	features := []Feature{}
+	for _, d := range details {
+		cars := []map[string]any{}
+		for _, car := range d.TrainConsist {
+			cars = append(cars, map[string]any{
+				"name":             car.Name,
+				"payload_mass":     car.PayloadMass,
+				"max_payload_mass": car.MaxPayloadMass,
+			})
+		}
+		features = append(features, newFeature(d.Location, map[string]any{
+			"name":             d.TrainName,
+			"status":           d.Status,
+			"train_station":    d.TrainStation,
+			"derailed":         d.Derailed,
+			"forward_speed":    d.ForwardSpeed,
+			"payload_mass":     d.PayloadMass,
+			"max_payload_mass": d.MaxPayloadMass,
+			"cars":             cars,
+			"power_consumed":   d.PowerConsumed,
+		}))
+	}
+	return features, nil
+}
+
+func vehicleFeatures(ctx context.Context, source Source) ([]Feature, error) {
+	details, err := frm.Routes{Getter: source}.GetVehicles(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	features := []Feature{}
+	for _, d := range details {
+		fuel := []map[string]any{}
+		for _, f := range d.Fuel {
+			fuel = append(fuel, map[string]any{
+				"item_name": f.Name,
+				"amount":    f.Amount,
+			})
+		}
+		features = append(features, newFeature(d.Location, map[string]any{
+			"id":            d.Id,
+			"vehicle_type":  d.VehicleType,
+			"path_name":     d.PathName,
+			"forward_speed": d.ForwardSpeed,
+			"autopilot":     d.AutoPilot,
+			"fuel":          fuel,
+		}))
+	}
+	return features, nil
+}
+
+func playerFeatures(ctx context.Context, source Source) ([]Feature, error) {
+	details, err := frm.Routes{Getter: source}.GetPlayer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	features := []Feature{}
+	for _, d := range details {
+		features = append(features, newFeature(d.Location, map[string]any{
+			"name": d.PlayerName,
+			"hp":   d.PlayerHP,
+			"dead": d.Dead,
+		}))
+	}
+	return features, nil
+}
+
+func droneStationFeatures(ctx context.Context, source Source) ([]Feature, error) {
+	details, err := frm.Routes{Getter: source}.GetDroneStation(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	features := []Feature{}
+	for _, d := range details {
+		features = append(features, newFeature(d.Location, map[string]any{
+			"id":                d.Id,
+			"home_station":      d.HomeStation,
+			"paired_station":    d.PairedStation,
+			"drone_status":      d.DroneStatus,
+			"est_transfer_rate": d.EstTransRate,
+			"est_round_trip":    d.EstRndTrip,
+			"latest_round_trip": d.LatestRndTrip,
+			"avg_incoming_rate": d.AvgIncRate,
+			"avg_outgoing_rate": d.AvgOutRate,
+			"est_battery_rate":  d.EstBatteryRate,
+			"power_consumed":    d.PowerInfo.PowerConsumed,
+		}))
+	}
+	return features, nil
+}
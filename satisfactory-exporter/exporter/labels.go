@@ -0,0 +1,185 @@
+package exporter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// LabelRule is the labeling policy of a metric, read from the configuration
+// file.
+type LabelRule struct {
+	// Name of the metric.
+	Metric string `yaml:"metric"`
+	// Labels kept on the metric, all of them when empty.
+	Keep []string `yaml:"keep"`
+	// Number of characters the geohash label is truncated to, 0 keeps it
+	// whole.
+	GeohashPrecision int `yaml:"geohash_precision"`
+	// How the series merged by the rule are combined: sum (default), avg,
+	// min or max.
+	Aggregation string `yaml:"aggregation"`
+}
+
+var labelAggregations = map[string]func(values []float64) float64{
+	"sum": func(values []float64) float64 {
+		total := 0.0
+		for _, v := range values {
+			total += v
+		}
+		return total
+	},
+	"avg": func(values []float64) float64 {
+		total := 0.0
+		for _, v := range values {
+			total += v
+		}
+		return total / float64(len(values))
+	},
+	"min": func(values []float64) float64 {
+		min := values[0]
+		for _, v := range values[1:] {
+			if v < min {
+				min = v
+			}
+		}
+		return min
+	},
+	"max": func(values []float64) float64 {
+		max := values[0]
+		for _, v := range values[1:] {
+			if v > max {
+				max = v
+			}
+		}
+		return max
+	},
+}
+
+// LabelPolicy rewrites the labels of the metrics sent by the collectors,
+// dropping the labels that are not kept and shortening the geohash. The series
+// that end up with the same labels are merged into one.
+type LabelPolicy struct {
+	rules map[*prometheus.Desc]*labelRule
+}
+
+type labelRule struct {
+	desc             *prometheus.Desc
+	keep             []string
+	geohashPrecision int
+	aggregate        func(values []float64) float64
+}
+
+// NewLabelPolicy checks the rules against the registered metrics.
+func NewLabelPolicy(rules []LabelRule) (*LabelPolicy, error) {
+	metrics := map[string]MetricVectorDetails{}
+	for _, m := range RegisteredMetricVectors {
+		metrics[m.Name] = m
+	}
+
+	p := &LabelPolicy{rules: map[*prometheus.Desc]*labelRule{}}
+	for _, r := range rules {
+		m, ok := metrics[r.Metric]
+		if !ok {
+			return nil, fmt.Errorf("labels: unknown metric %q", r.Metric)
+		}
+		if _, ok := p.rules[m.Desc]; ok {
+			return nil, fmt.Errorf("labels: duplicate rule for %s", r.Metric)
+		}
+
+		keep := r.Keep
+		if len(keep) == 0 {
+			keep = m.Labels
+		}
+		for _, name := range keep {
+			if !containsLabel(m.Labels, name) {
+				return nil, fmt.Errorf("labels: %s has no label %q, expected one of: %s", r.Metric, name, strings.Join(m.Labels, ", "))
+			}
+		}
+
+		aggregation := r.Aggregation
+		if aggregation == "" {
+			aggregation = "sum"
+		}
+		aggregate, ok := labelAggregations[aggregation]
+		if !ok {
+			return nil, fmt.Errorf("labels: unknown aggregation %q for %s, expected one of: sum, avg, min, max", r.Aggregation, r.Metric)
+		}
+
+		p.rules[m.Desc] = &labelRule{
+			desc:             prometheus.NewDesc(m.Name, m.Help, keep, nil),
+			keep:             keep,
+			geohashPrecision: r.GeohashPrecision,
+			aggregate:        aggregate,
+		}
+	}
+	return p, nil
+}
+
+func containsLabel(labels []string, name string) bool {
+	for _, l := range labels {
+		if l == name {
+			return true
+		}
+	}
+	return false
+}
+
+type labelGroup struct {
+	rule   *labelRule
+	labels []string
+	values []float64
+}
+
+// Apply rewrites the metrics covered by a rule, the others are returned as
+// is. A nil policy leaves every metric untouched.
+func (p *LabelPolicy) Apply(metrics []prometheus.Metric) []prometheus.Metric {
+	if p == nil || len(p.rules) == 0 {
+		return metrics
+	}
+
+	result := []prometheus.Metric{}
+	groups := map[string]*labelGroup{}
+	order := []string{}
+	for _, m := range metrics {
+		rule, ok := p.rules[m.Desc()]
+		if !ok {
+			result = append(result, m)
+			continue
+		}
+		pb := &dto.Metric{}
+		if err := m.Write(pb); err != nil || pb.Gauge == nil {
+			result = append(result, m)
+			continue
+		}
+
+		values := map[string]string{}
+		for _, l := range pb.Label {
+			values[l.GetName()] = l.GetValue()
+		}
+		labels := make([]string, len(rule.keep))
+		for i, name := range rule.keep {
+			labels[i] = values[name]
+			if name == "geohash" && rule.geohashPrecision > 0 && len(labels[i]) > rule.geohashPrecision {
+				labels[i] = labels[i][:rule.geohashPrecision]
+			}
+		}
+
+		key := fmt.Sprintf("%p\xff%s", rule, strings.Join(labels, "\xff"))
+		group, ok := groups[key]
+		if !ok {
+			group = &labelGroup{rule: rule, labels: labels}
+			groups[key] = group
+			order = append(order, key)
+		}
+		group.values = append(group.values, pb.Gauge.GetValue())
+	}
+
+	for _, key := range order {
+		g := groups[key]
+		result = append(result, prometheus.MustNewConstMetric(g.rule.desc, prometheus.GaugeValue, g.rule.aggregate(g.values), g.labels...))
+	}
+	return result
+}
@@ -0,0 +1,163 @@
+package exporter
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// extractorSample is a series of extractor_items_produced_per_min.
+func extractorSample(item, id, geohash string, value float64) prometheus.Metric {
+	return prometheus.MustNewConstMetric(ExtractorItemsProducedPerMin, prometheus.GaugeValue, value, item, id, "Miner Mk.1", geohash, "0", "0", "0")
+}
+
+// seriesValues returns the values of the metrics by their labels, formatted
+// as name=value pairs sorted by name.
+func seriesValues(t *testing.T, metrics []prometheus.Metric) map[string]float64 {
+	t.Helper()
+	values := map[string]float64{}
+	for _, m := range metrics {
+		pb := &dto.Metric{}
+		if err := m.Write(pb); err != nil {
+			t.Fatal(err)
+		}
+		labels := []string{}
+		for _, l := range pb.Label {
+			labels = append(labels, l.GetName()+"="+l.GetValue())
+		}
+		key := strings.Join(labels, ",")
+		if _, ok := values[key]; ok {
+			t.Errorf("duplicate series %s", key)
+		}
+		values[key] = pb.Gauge.GetValue()
+	}
+	return values
+}
+
+func TestLabelPolicyApply(t *testing.T) {
+	samples := []prometheus.Metric{
+		extractorSample("Iron Ore", "Miner_1", "u4pruyd", 60),
+		extractorSample("Iron Ore", "Miner_2", "u4pzz01", 120),
+		extractorSample("Iron Ore", "Miner_3", "9q8yyk8", 30),
+		extractorSample("Copper Ore", "Miner_4", "u4pruyd", 240),
+	}
+
+	tests := []struct {
+		name string
+		rule LabelRule
+		want map[string]float64
+	}{{
+		name: "keep every label",
+		rule: LabelRule{Metric: "extractor_items_produced_per_min"},
+		want: map[string]float64{
+			"geohash=u4pruyd,id=Miner_1,item_name=Iron Ore,machine_name=Miner Mk.1,x=0,y=0,z=0":   60,
+			"geohash=u4pzz01,id=Miner_2,item_name=Iron Ore,machine_name=Miner Mk.1,x=0,y=0,z=0":   120,
+			"geohash=9q8yyk8,id=Miner_3,item_name=Iron Ore,machine_name=Miner Mk.1,x=0,y=0,z=0":   30,
+			"geohash=u4pruyd,id=Miner_4,item_name=Copper Ore,machine_name=Miner Mk.1,x=0,y=0,z=0": 240,
+		},
+	}, {
+		name: "keep without collisions",
+		rule: LabelRule{Metric: "extractor_items_produced_per_min", Keep: []string{"id"}},
+		want: map[string]float64{
+			"id=Miner_1": 60,
+			"id=Miner_2": 120,
+			"id=Miner_3": 30,
+			"id=Miner_4": 240,
+		},
+	}, {
+		name: "sum of collisions",
+		rule: LabelRule{Metric: "extractor_items_produced_per_min", Keep: []string{"item_name"}},
+		want: map[string]float64{
+			"item_name=Iron Ore":   210,
+			"item_name=Copper Ore": 240,
+		},
+	}, {
+		name: "avg of collisions",
+		rule: LabelRule{Metric: "extractor_items_produced_per_min", Keep: []string{"item_name"}, Aggregation: "avg"},
+		want: map[string]float64{
+			"item_name=Iron Ore":   70,
+			"item_name=Copper Ore": 240,
+		},
+	}, {
+		name: "min of collisions",
+		rule: LabelRule{Metric: "extractor_items_produced_per_min", Keep: []string{"item_name"}, Aggregation: "min"},
+		want: map[string]float64{
+			"item_name=Iron Ore":   30,
+			"item_name=Copper Ore": 240,
+		},
+	}, {
+		name: "max of collisions",
+		rule: LabelRule{Metric: "extractor_items_produced_per_min", Keep: []string{"item_name"}, Aggregation: "max"},
+		want: map[string]float64{
+			"item_name=Iron Ore":   120,
+			"item_name=Copper Ore": 240,
+		},
+	}, {
+		name: "geohash precision",
+		rule: LabelRule{Metric: "extractor_items_produced_per_min", Keep: []string{"item_name", "geohash"}, GeohashPrecision: 3},
+		want: map[string]float64{
+			"geohash=u4p,item_name=Iron Ore":   180,
+			"geohash=9q8,item_name=Iron Ore":   30,
+			"geohash=u4p,item_name=Copper Ore": 240,
+		},
+	}, {
+		name: "geohash precision longer than the geohash",
+		rule: LabelRule{Metric: "extractor_items_produced_per_min", Keep: []string{"geohash"}, GeohashPrecision: 12},
+		want: map[string]float64{
+			"geohash=u4pruyd": 300,
+			"geohash=u4pzz01": 120,
+			"geohash=9q8yyk8": 30,
+		},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			policy, err := NewLabelPolicy([]LabelRule{test.rule})
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := seriesValues(t, policy.Apply(samples))
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestLabelPolicyApplyOtherMetrics(t *testing.T) {
+	policy, err := NewLabelPolicy([]LabelRule{{Metric: "extractor_items_produced_per_min", Keep: []string{"item_name"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	other := prometheus.MustNewConstMetric(ExtractorPurity, prometheus.GaugeValue, 1, "Miner_1", "Miner Mk.1", "Normal", "u4pruyd", "0", "0", "0")
+	got := policy.Apply([]prometheus.Metric{other})
+	if len(got) != 1 || got[0] != other {
+		t.Errorf("a metric without a rule was rewritten: %v", got)
+	}
+
+	var none *LabelPolicy
+	samples := []prometheus.Metric{extractorSample("Iron Ore", "Miner_1", "u4pruyd", 60)}
+	if got := none.Apply(samples); !reflect.DeepEqual(got, samples) {
+		t.Errorf("a nil policy rewrote the metrics: %v", got)
+	}
+}
+
+func TestNewLabelPolicyErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		rules []LabelRule
+	}{
+		{name: "unknown metric", rules: []LabelRule{{Metric: "no_such_metric"}}},
+		{name: "unknown label", rules: []LabelRule{{Metric: "extractor_items_produced_per_min", Keep: []string{"purity"}}}},
+		{name: "unknown aggregation", rules: []LabelRule{{Metric: "extractor_items_produced_per_min", Aggregation: "median"}}},
+		{name: "duplicate rule", rules: []LabelRule{{Metric: "extractor_purity"}, {Metric: "extractor_purity"}}},
+	}
+	for _, test := range tests {
+		if _, err := NewLabelPolicy(test.rules); err == nil {
+			t.Errorf("%s: no error", test.name)
+		}
+	}
+}
@@ -1,25 +1,29 @@
 package exporter
 
-type Location struct {
-	X        float64 `json:"x"`
-	Y        float64 `json:"y"`
-	Z        float64 `json:"z"`
-	Rotation int     `json:"rotation"`
-}
+import (
+	"strconv"
+
+	"github.com/justereseau/satisfactory-metrics/satisfactory-exporter/frm"
+	"github.com/justereseau/satisfactory-metrics/satisfactory-exporter/projection"
+	"github.com/pierrre/geohash"
+)
 
-// // Calculates if a location is nearby another.
-// // From observation, 5000 units is "good enough" to be considered nearby.
-// func (l *Location) isNearby(other Location) bool {
-// 	x := l.X - other.X
-// 	y := l.Y - other.Y
-// 	z := l.Z - other.Z
+// Projection of the locations exported in the metrics.
+var MapProjection projection.Projection = projection.Geomap{}
 
-// 	dist := math.Sqrt(math.Pow(x, 2) + math.Pow(y, 2) + math.Pow(z, 2))
-// 	return dist <= 5000
-// }
+// project converts a location with MapProjection.
+func project(l frm.Location) projection.Point {
+	return MapProjection.Project(l.X, l.Y, l.Z)
+}
 
-// // Calculates if this location is roughly facing the same way as another
-// func (l *Location) isSameDirection(other Location) bool {
-// 	diff := math.Abs(float64(l.Rotation - other.Rotation))
-// 	return diff <= 90
-// }
+// locationLabels returns the geohash, x, y and z labels of a location. The
+// geohash is always computed with the geomap projection, as it needs a
+// latitude and a longitude.
+func locationLabels(l frm.Location) (string, string, string, string) {
+	geo := projection.Geomap{}.Project(l.X, l.Y, l.Z)
+	p := project(l)
+	return geohash.EncodeAuto(geo.Y, geo.X),
+		strconv.FormatFloat(p.X, 'f', -1, 64),
+		strconv.FormatFloat(p.Y, 'f', -1, 64),
+		strconv.FormatFloat(p.Z, 'f', -1, 64)
+}
@@ -97,6 +97,7 @@ var (
 		Help: "Power consumed on selected power circuit",
 	}, []string{
 		"circuit_id",
+		"circuit_name",
 	})
 
 	PowerCapacity = RegisterNewGaugeVec(prometheus.GaugeOpts{
@@ -104,6 +105,7 @@ var (
 		Help: "Power capacity on selected power circuit",
 	}, []string{
 		"circuit_id",
+		"circuit_name",
 	})
 
 	PowerMaxConsumed = RegisterNewGaugeVec(prometheus.GaugeOpts{
@@ -111,6 +113,7 @@ var (
 		Help: "Maximum Power that can be consumed on selected power circuit",
 	}, []string{
 		"circuit_id",
+		"circuit_name",
 	})
 
 	BatteryDifferential = RegisterNewGaugeVec(prometheus.GaugeOpts{
@@ -118,6 +121,7 @@ var (
 		Help: "Amount of power in excess/deficit going into or out of the battery bank(s). Positive = Charges batteries, Negative = Drains batteries",
 	}, []string{
 		"circuit_id",
+		"circuit_name",
 	})
 
 	BatteryPercent = RegisterNewGaugeVec(prometheus.GaugeOpts{
@@ -125,6 +129,7 @@ var (
 		Help: "Percentage of battery bank(s) charge",
 	}, []string{
 		"circuit_id",
+		"circuit_name",
 	})
 
 	BatteryCapacity = RegisterNewGaugeVec(prometheus.GaugeOpts{
@@ -132,6 +137,7 @@ var (
 		Help: "Total capacity of battery bank(s)",
 	}, []string{
 		"circuit_id",
+		"circuit_name",
 	})
 
 	BatterySecondsEmpty = RegisterNewGaugeVec(prometheus.GaugeOpts{
@@ -139,6 +145,7 @@ var (
 		Help: "Seconds until Batteries are empty",
 	}, []string{
 		"circuit_id",
+		"circuit_name",
 	})
 
 	BatterySecondsFull = RegisterNewGaugeVec(prometheus.GaugeOpts{
@@ -146,6 +153,7 @@ var (
 		Help: "Seconds until Batteries are full",
 	}, []string{
 		"circuit_id",
+		"circuit_name",
 	})
 
 	FuseTriggered = RegisterNewGaugeVec(prometheus.GaugeOpts{
@@ -153,6 +161,56 @@ var (
 		Help: "Has the fuse been triggered",
 	}, []string{
 		"circuit_id",
+		"circuit_name",
+	})
+
+	CircuitBuildings = RegisterNewGaugeVec(prometheus.GaugeOpts{
+		Name: "circuit_buildings",
+		Help: "Number of factory buildings on selected power circuit",
+	}, []string{
+		"circuit_id",
+		"circuit_name",
+	})
+
+	CircuitChangeInfo = RegisterNewGaugeVec(prometheus.GaugeOpts{
+		Name: "circuit_change_info",
+		Help: "Last merge or split of selected power circuit, with the circuits it was merged from or split from",
+	}, []string{
+		"circuit_id",
+		"circuit_name",
+		"change",
+		"circuits",
+	})
+
+	CircuitChanges = RegisterNewCounterVec(prometheus.CounterOpts{
+		Name: "circuit_changes_total",
+		Help: "Total number of power circuit merges and splits detected since the exporter started, by kind of change",
+	}, []string{
+		"change",
+	})
+
+	PowerHeadroom = RegisterNewGaugeVec(prometheus.GaugeOpts{
+		Name: "power_headroom",
+		Help: "Power capacity minus power consumed on selected power circuit, in MW. Negative when running on batteries",
+	}, []string{
+		"circuit_id",
+		"circuit_name",
+	})
+
+	PowerConsumedTrend = RegisterNewGaugeVec(prometheus.GaugeOpts{
+		Name: "power_consumed_trend",
+		Help: "Trend of the power consumed on selected power circuit over the last minutes, in MW per minute",
+	}, []string{
+		"circuit_id",
+		"circuit_name",
+	})
+
+	PowerFuseTripSeconds = RegisterNewGaugeVec(prometheus.GaugeOpts{
+		Name: "power_fuse_trip_seconds",
+		Help: "Predicted time until the fuse of selected power circuit trips, in seconds. Only set when a trip is expected",
+	}, []string{
+		"circuit_id",
+		"circuit_name",
 	})
 
 	VehicleFuel = RegisterNewGaugeVec(prometheus.GaugeOpts{
@@ -164,6 +222,44 @@ var (
 		"fuel_type",
 		"fuel_index",
 	})
+	VehicleRoundTrip = RegisterNewGaugeVec(prometheus.GaugeOpts{
+		Name: "vehicle_round_trip_seconds",
+		Help: "Recorded self-driving vehicle round trip time in seconds",
+	}, []string{
+		"id",
+		"vehicle_type",
+		"path_name",
+	})
+	VehicleTripElapsed = RegisterNewGaugeVec(prometheus.GaugeOpts{
+		Name: "vehicle_trip_elapsed_seconds",
+		Help: "Time since the self-driving vehicle left its station, in seconds. Only set while the vehicle is on a trip",
+	}, []string{
+		"id",
+		"vehicle_type",
+		"path_name",
+	})
+	VehicleForwardSpeed = RegisterNewGaugeVec(prometheus.GaugeOpts{
+		Name: "vehicle_forward_speed",
+		Help: "The current forward speed of the vehicle",
+	}, []string{
+		"id",
+		"vehicle_type",
+	})
+	VehicleAutoPilot = RegisterNewGaugeVec(prometheus.GaugeOpts{
+		Name: "vehicle_autopilot",
+		Help: "Is the vehicle self-driving",
+	}, []string{
+		"id",
+		"vehicle_type",
+	})
+	VehiclePosition = RegisterNewGaugeVec(prometheus.GaugeOpts{
+		Name: "vehicle_current_position",
+		Help: "The current position of the vehicle, per axis",
+	}, []string{
+		"id",
+		"vehicle_type",
+		"axis_name",
+	})
 
 	DronePortBatteryRate = RegisterNewGaugeVec(prometheus.GaugeOpts{
 		Name: "drone_port_battery_rate",
@@ -188,6 +284,14 @@ var (
 		Help: "Drone port power in MW",
 	}, []string{
 		"circuit_id",
+		"circuit_name",
+	})
+	DronePortPowerMax = RegisterNewGaugeVec(prometheus.GaugeOpts{
+		Name: "drone_port_power_max",
+		Help: "Drone port max power in MW",
+	}, []string{
+		"circuit_id",
+		"circuit_name",
 	})
 
 	VehicleStationPower = RegisterNewGaugeVec(prometheus.GaugeOpts{
@@ -195,12 +299,14 @@ var (
 		Help: "Vehicle station power use in MW",
 	}, []string{
 		"circuit_id",
+		"circuit_name",
 	})
 	VehicleStationPowerMax = RegisterNewGaugeVec(prometheus.GaugeOpts{
 		Name: "vehicle_station_power_max",
 		Help: "Vehicle station max power use in MW",
 	}, []string{
 		"circuit_id",
+		"circuit_name",
 	})
 
 	TrainRoundTrip = RegisterNewGaugeVec(prometheus.GaugeOpts{
@@ -217,6 +323,20 @@ var (
 		"from",
 		"to",
 	})
+	TrainRoundTripAvg = RegisterNewGaugeVec(prometheus.GaugeOpts{
+		Name: "train_round_trip_avg_seconds",
+		Help: "Average of the last recorded train round trip times in seconds",
+	}, []string{
+		"name",
+	})
+	TrainSegmentTripAvg = RegisterNewGaugeVec(prometheus.GaugeOpts{
+		Name: "train_segment_trip_avg_seconds",
+		Help: "Average of the last recorded train trips between two stations in seconds",
+	}, []string{
+		"name",
+		"from",
+		"to",
+	})
 	TrainDerailed = RegisterNewGaugeVec(prometheus.GaugeOpts{
 		Name: "train_derailed",
 		Help: "Is train derailed",
@@ -229,12 +349,12 @@ var (
 	}, []string{
 		"name",
 	})
-	TrainDrivingStatus = RegisterNewGaugeVec(prometheus.GaugeOpts{
+	TrainDrivingStatus = RegisterNewStateSet(prometheus.GaugeOpts{
 		Name: "train_driving_status",
-		Help: "The current autopilot status of the train. 0 = Manual, 1 = Autopilot",
+		Help: "The current driving status of the train, 1 for the current status and 0 for the others",
 	}, []string{
 		"name",
-	})
+	}, "Parked", "Manual Driving", "Self-Driving")
 	TrainForwardSpeed = RegisterNewGaugeVec(prometheus.GaugeOpts{
 		Name: "train_forward_speed",
 		Help: "The current forward speed of the train",
@@ -258,12 +378,14 @@ var (
 		Help: "How much power all trains are consuming in a circuit",
 	}, []string{
 		"circuit_id",
+		"circuit_name",
 	})
 	TrainCircuitPowerMax = RegisterNewGaugeVec(prometheus.GaugeOpts{
 		Name: "train_power_circuit_consumed_max",
 		Help: "Maximum power all trains can consume on a circuit",
 	}, []string{
 		"circuit_id",
+		"circuit_name",
 	})
 	TrainTotalMass = RegisterNewGaugeVec(prometheus.GaugeOpts{
 		Name: "train_total_mass",
@@ -289,11 +411,51 @@ var (
 		Help: "Train station power consumed in MW",
 	}, []string{
 		"circuit_id",
+		"circuit_name",
 	})
 	TrainStationPowerMax = RegisterNewGaugeVec(prometheus.GaugeOpts{
 		Name: "train_station_power_max",
 		Help: "Train station power max consumed in MW",
 	}, []string{
 		"circuit_id",
+		"circuit_name",
+	})
+
+	RouteUp = RegisterNewGaugeVec(prometheus.GaugeOpts{
+		Name: "ficsit_route_up",
+		Help: "Whether the last background poll of the FRM route succeeded",
+	}, []string{
+		"route",
+	})
+	RoutePollInterval = RegisterNewGaugeVec(prometheus.GaugeOpts{
+		Name: "ficsit_route_poll_interval_seconds",
+		Help: "Interval between two background polls of the FRM route",
+	}, []string{
+		"route",
+	})
+	RouteLastSuccess = RegisterNewGaugeVec(prometheus.GaugeOpts{
+		Name: "ficsit_route_last_success_timestamp_seconds",
+		Help: "Unix timestamp of the last successful poll of the FRM route",
+	}, []string{
+		"route",
+	})
+	RouteStaleness = RegisterNewGaugeVec(prometheus.GaugeOpts{
+		Name: "ficsit_route_staleness_seconds",
+		Help: "Age of the data served for the FRM route, in seconds",
+	}, []string{
+		"route",
+	})
+
+	ScrapeCollectorDuration = RegisterNewGaugeVec(prometheus.GaugeOpts{
+		Name: "ficsit_scrape_collector_duration_seconds",
+		Help: "Duration of a collector scrape, in seconds",
+	}, []string{
+		"collector",
+	})
+	ScrapeCollectorSuccess = RegisterNewGaugeVec(prometheus.GaugeOpts{
+		Name: "ficsit_scrape_collector_success",
+		Help: "Whether a collector succeeded",
+	}, []string{
+		"collector",
 	})
 )
@@ -0,0 +1,103 @@
+package exporter
+
+import (
+	"sync"
+	"time"
+
+	"github.com/justereseau/satisfactory-metrics/satisfactory-exporter/frm"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// PlatformTracker follows the cargo platforms of the train stations across
+// polls to time how long trains stay docked.
+//
+// A train is considered docked at a platform while the platform is loading or
+// unloading, the durations are an approximation bounded by the poll interval.
+type PlatformTracker struct {
+	mu        sync.Mutex
+	platforms map[string]*platformState // by platform ID
+	observed  time.Time                 // time of the last observation
+}
+
+type platformState struct {
+	station  string    // ID of the station
+	name     string    // name of the station
+	docked   time.Time // when the current train docked, zero while idle
+	observed time.Time
+	total    time.Duration
+	docks    tripDurations
+}
+
+func NewPlatformTracker() *PlatformTracker {
+	return &PlatformTracker{
+		platforms: map[string]*platformState{},
+	}
+}
+
+// platformBusy tells whether a train is being loaded or unloaded at a
+// platform.
+func platformBusy(p frm.CargoPlatform) bool {
+	return p.LoadingStatus != "" && p.LoadingStatus != "Idle"
+}
+
+// Observe records the state of the platforms of the stations at the given
+// time. Platforms that are not part of the observation are forgotten.
+// Observations that are not newer than the last one are ignored.
+func (t *PlatformTracker) Observe(now time.Time, stations []frm.TrainStationDetails) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if !now.After(t.observed) {
+		return
+	}
+	t.observed = now
+
+	seen := map[string]bool{}
+	for _, d := range stations {
+		for _, p := range d.CargoPlatforms {
+			seen[p.Id] = true
+			state, ok := t.platforms[p.Id]
+			if !ok {
+				state = &platformState{}
+				t.platforms[p.Id] = state
+			}
+			state.station, state.name = d.Id, d.Name
+
+			busy := platformBusy(p)
+			if !state.docked.IsZero() {
+				state.total += now.Sub(state.observed)
+				if !busy {
+					state.docks.add(now.Sub(state.docked))
+					state.docked = time.Time{}
+				}
+			} else if busy && ok {
+				state.docked = now
+			}
+			state.observed = now
+		}
+	}
+
+	for key := range t.platforms {
+		if !seen[key] {
+			delete(t.platforms, key)
+		}
+	}
+}
+
+func (t *PlatformTracker) collect(now time.Time, ch chan<- prometheus.Metric) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for id, state := range t.platforms {
+		docked := 0.0
+		if !state.docked.IsZero() {
+			docked = now.Sub(state.docked).Seconds()
+		}
+		ch <- prometheus.MustNewConstMetric(TrainPlatformDocked, prometheus.GaugeValue, docked, id, state.station, state.name)
+		ch <- prometheus.MustNewConstMetric(TrainPlatformDockedTotal, prometheus.CounterValue, state.total.Seconds(), id, state.station, state.name)
+		if len(state.docks.durations) > 0 {
+			ch <- prometheus.MustNewConstMetric(TrainPlatformDock, prometheus.GaugeValue, state.docks.latest().Seconds(), id, state.station, state.name)
+			ch <- prometheus.MustNewConstMetric(TrainPlatformDockAvg, prometheus.GaugeValue, state.docks.average().Seconds(), id, state.station, state.name)
+		}
+	}
+}
@@ -1,55 +1,36 @@
 package exporter
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/go-kit/log"
-	"github.com/go-kit/log/level"
+	"github.com/justereseau/satisfactory-metrics/satisfactory-exporter/frm"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
 type PlayerCollector struct {
-	frmTarget string
-	logger    log.Logger
+	source Source
+	logger log.Logger
 }
 
-type TagColor struct {
-	R float64 `json:"R"`
-	G float64 `json:"G"`
-	B float64 `json:"B"`
-	A float64 `json:"A"`
-}
-
-type PlayerDetails struct {
-	ID         float64  `json:"ID"`
-	PlayerName string   `json:"PlayerName"`
-	PlayerHP   float64  `json:"PlayerHP"`
-	Dead       bool     `json:"Dead"`
-	PingTime   float64  `json:"PingTime"`
-	Location   Location `json:"Location"`
-	TagColor   TagColor `json:"TagColor"`
-}
-
-func NewPlayerCollector(frmApiAddress string, logger log.Logger) *PlayerCollector {
+func NewPlayerCollector(source Source, logger log.Logger) *PlayerCollector {
 	return &PlayerCollector{
-		frmTarget: frmApiAddress + "/getPlayer",
-		logger:    logger,
+		source: source,
+		logger: logger,
 	}
 }
 
-func (c PlayerCollector) Describe(ch chan<- *prometheus.Desc) {}
-
-func (c *PlayerCollector) Collect(ch chan<- prometheus.Metric) {
-	details := []PlayerDetails{}
-	err := retrieveData(c.frmTarget, &details)
+func (c *PlayerCollector) Update(ctx context.Context, ch chan<- prometheus.Metric) error {
+	details, err := frm.Routes{Getter: c.source}.GetPlayer(ctx)
 	if err != nil {
-		level.Error(c.logger).Log("msg", "Error reading trains statistics from Ficsit Metrics", "err", err)
-		return
+		return err
 	}
 
 	for _, d := range details {
-		ch <- prometheus.MustNewConstMetric(PlayerPosition, prometheus.GaugeValue, d.Location.X*0.000239930467-79.70308527, d.PlayerName, fmt.Sprintf("%f", d.ID), "X")
-		ch <- prometheus.MustNewConstMetric(PlayerPosition, prometheus.GaugeValue, -d.Location.Y*0.0001413589137+36.97720935, d.PlayerName, fmt.Sprintf("%f", d.ID), "Y")
+		p := project(d.Location)
+		ch <- prometheus.MustNewConstMetric(PlayerPosition, prometheus.GaugeValue, p.X, d.PlayerName, fmt.Sprintf("%f", d.ID), "X")
+		ch <- prometheus.MustNewConstMetric(PlayerPosition, prometheus.GaugeValue, p.Y, d.PlayerName, fmt.Sprintf("%f", d.ID), "Y")
 		ch <- prometheus.MustNewConstMetric(PlayerPosition, prometheus.GaugeValue, d.Location.Z, d.PlayerName, fmt.Sprintf("%f", d.ID), "Z")
 		ch <- prometheus.MustNewConstMetric(PlayerRotation, prometheus.GaugeValue, float64(d.Location.Rotation), d.PlayerName, fmt.Sprintf("%f", d.ID))
 		ch <- prometheus.MustNewConstMetric(PlayerHealth, prometheus.GaugeValue, d.PlayerHP, d.PlayerName, fmt.Sprintf("%f", d.ID))
@@ -60,4 +41,5 @@ func (c *PlayerCollector) Collect(ch chan<- prometheus.Metric) {
 		ch <- prometheus.MustNewConstMetric(PlayerTagColor, prometheus.GaugeValue, d.TagColor.B, d.PlayerName, fmt.Sprintf("%f", d.ID), "B")
 		ch <- prometheus.MustNewConstMetric(PlayerTagColor, prometheus.GaugeValue, d.TagColor.A, d.PlayerName, fmt.Sprintf("%f", d.ID), "A")
 	}
+	return nil
 }
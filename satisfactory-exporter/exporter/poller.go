@@ -0,0 +1,165 @@
+package exporter
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/justereseau/satisfactory-metrics/satisfactory-exporter/frm"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var errNoSnapshot = errors.New("no successful poll of the route yet")
+
+// Poller fetches FRM routes in the background, each on its own interval, and
+// keeps the last payload received so that scrapes never wait on the game.
+//
+// Routes are polled lazily: the first Get for a route fetches it once
+// synchronously and then starts a goroutine that refreshes it until the
+// context given to NewPoller is cancelled.
+type Poller struct {
+	ctx             context.Context
+	client          *frm.Client
+	defaultInterval time.Duration
+	intervals       map[string]time.Duration
+	logger          log.Logger
+
+	mu        sync.RWMutex
+	snapshots map[string]*snapshot
+}
+
+type snapshot struct {
+	data        []byte
+	lastSuccess time.Time
+	lastAttempt time.Time
+	err         error
+	ready       chan struct{}
+}
+
+func NewPoller(ctx context.Context, client *frm.Client, defaultInterval time.Duration, intervals map[string]time.Duration, logger log.Logger) *Poller {
+	return &Poller{
+		ctx:             ctx,
+		client:          client,
+		defaultInterval: defaultInterval,
+		intervals:       intervals,
+		logger:          logger,
+		snapshots:       map[string]*snapshot{},
+	}
+}
+
+// Get decodes the last payload received for the route into details.
+func (p *Poller) Get(ctx context.Context, route string, details any) error {
+	_, err := p.GetSnapshot(ctx, route, details)
+	return err
+}
+
+// GetSnapshot decodes the last payload received for the route into details,
+// and returns when it was received.
+func (p *Poller) GetSnapshot(ctx context.Context, route string, details any) (time.Time, error) {
+	s := p.snapshot(route)
+	select {
+	case <-s.ready:
+	case <-ctx.Done():
+		return time.Time{}, ctx.Err()
+	}
+
+	p.mu.RLock()
+	data, err, fetched := s.data, s.err, s.lastSuccess
+	p.mu.RUnlock()
+
+	if data == nil {
+		if err == nil {
+			err = errNoSnapshot
+		}
+		return time.Time{}, err
+	}
+	return fetched, frm.Decode(route, data, details)
+}
+
+func (p *Poller) interval(route string) time.Duration {
+	if interval, ok := p.intervals[route]; ok {
+		return interval
+	}
+	return p.defaultInterval
+}
+
+// snapshot returns the snapshot of the route, starting its polling if it is
+// the first time the route is requested.
+func (p *Poller) snapshot(route string) *snapshot {
+	p.mu.RLock()
+	s, ok := p.snapshots[route]
+	p.mu.RUnlock()
+	if ok {
+		return s
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if s, ok = p.snapshots[route]; ok {
+		return s
+	}
+	s = &snapshot{ready: make(chan struct{})}
+	p.snapshots[route] = s
+	go p.poll(route, s)
+	return s
+}
+
+func (p *Poller) poll(route string, s *snapshot) {
+	interval := p.interval(route)
+	level.Info(p.logger).Log("msg", "Starting to poll route", "route", route, "interval", interval)
+
+	p.refresh(route, s)
+	close(s.ready)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-p.ctx.Done():
+			return
+		case <-ticker.C:
+			p.refresh(route, s)
+		}
+	}
+}
+
+func (p *Poller) refresh(route string, s *snapshot) {
+	start := time.Now()
+	data, err := p.client.GetRaw(p.ctx, route)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	s.lastAttempt = start
+	s.err = err
+	if err != nil {
+		level.Warn(p.logger).Log("msg", "Error polling route", "route", route, "err", err)
+		return
+	}
+	s.data = data
+	s.lastSuccess = start
+	level.Debug(p.logger).Log("msg", "Route polled", "route", route, "duration", time.Since(start).Seconds())
+}
+
+func (p *Poller) Describe(ch chan<- *prometheus.Desc) {}
+
+func (p *Poller) Collect(ch chan<- prometheus.Metric) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	now := time.Now()
+	for route, s := range p.snapshots {
+		if s.lastAttempt.IsZero() {
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(RouteUp, prometheus.GaugeValue, parseBool(s.err == nil), route)
+		ch <- prometheus.MustNewConstMetric(RoutePollInterval, prometheus.GaugeValue, p.interval(route).Seconds(), route)
+		if s.lastSuccess.IsZero() {
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(RouteLastSuccess, prometheus.GaugeValue, float64(s.lastSuccess.UnixNano())/1e9, route)
+		ch <- prometheus.MustNewConstMetric(RouteStaleness, prometheus.GaugeValue, now.Sub(s.lastSuccess).Seconds(), route)
+	}
+}
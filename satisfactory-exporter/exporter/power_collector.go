@@ -1,69 +1,53 @@
 package exporter
 
 import (
-	"strconv"
+	"context"
 
 	"github.com/go-kit/log"
-	"github.com/go-kit/log/level"
+	"github.com/justereseau/satisfactory-metrics/satisfactory-exporter/frm"
 
 	"github.com/prometheus/client_golang/prometheus"
 )
 
-type PowerInfo struct {
-	CircuitId     float64 `json:"ID"`
-	PowerConsumed float64 `json:"PowerConsumed"`
-}
-
 type PowerCollector struct {
-	frmTarget string
-	logger    log.Logger
+	source     Source
+	forecaster *PowerForecaster
+	circuits   *CircuitTracker
+	logger     log.Logger
 }
 
-type PowerDetails struct {
-	CircuitId           float64 `json:"CircuitID"`
-	PowerConsumed       float64 `json:"PowerConsumed"`
-	PowerCapacity       float64 `json:"PowerCapacity"`
-	PowerMaxConsumed    float64 `json:"PowerMaxConsumed"`
-	BatteryDifferential float64 `json:"BatteryDifferential"`
-	BatteryPercent      float64 `json:"BatteryPercent"`
-	BatteryCapacity     float64 `json:"BatteryCapacity"`
-	BatteryTimeEmpty    string  `json:"BatteryTimeEmpty"`
-	BatteryTimeFull     string  `json:"BatteryTimeFull"`
-	FuseTriggered       bool    `json:"FuseTriggered"`
-}
-
-func NewPowerCollector(frmApiAddress string, logger log.Logger) *PowerCollector {
+func NewPowerCollector(source Source, forecaster *PowerForecaster, circuits *CircuitTracker, logger log.Logger) *PowerCollector {
 	return &PowerCollector{
-		frmTarget: frmApiAddress + "/getPower",
-		logger:    logger,
+		source:     source,
+		forecaster: forecaster,
+		circuits:   circuits,
+		logger:     logger,
 	}
 }
-func (c PowerCollector) Describe(ch chan<- *prometheus.Desc) {}
-
-func (c *PowerCollector) Collect(ch chan<- prometheus.Metric) {
-	details := []PowerDetails{}
-	err := retrieveData(c.frmTarget, &details)
+func (c *PowerCollector) Update(ctx context.Context, ch chan<- prometheus.Metric) error {
+	source := &observedSource{Source: c.source}
+	details, err := frm.Routes{Getter: source}.GetPower(ctx)
 	if err != nil {
-		level.Error(c.logger).Log("msg", "Error reading power statistics from Ficsit Metrics", "err", err)
-		return
+		return err
 	}
+	c.forecaster.Observe(source.observed, details)
+	c.forecaster.collect(ch, c.circuits)
 
 	for _, d := range details {
-		circuitId := strconv.FormatFloat(d.CircuitId, 'f', -1, 64)
-		ch <- prometheus.MustNewConstMetric(PowerConsumed, prometheus.GaugeValue, d.PowerConsumed, circuitId)
-		ch <- prometheus.MustNewConstMetric(PowerCapacity, prometheus.GaugeValue, d.PowerCapacity, circuitId)
-		ch <- prometheus.MustNewConstMetric(PowerMaxConsumed, prometheus.GaugeValue, d.PowerMaxConsumed, circuitId)
-		ch <- prometheus.MustNewConstMetric(BatteryDifferential, prometheus.GaugeValue, d.BatteryDifferential, circuitId)
-		ch <- prometheus.MustNewConstMetric(BatteryPercent, prometheus.GaugeValue, d.BatteryPercent, circuitId)
-		ch <- prometheus.MustNewConstMetric(BatteryCapacity, prometheus.GaugeValue, d.BatteryCapacity, circuitId)
-		batterySecondsRemaining := parseTimeSeconds(d.BatteryTimeEmpty)
-		if batterySecondsRemaining != nil {
-			ch <- prometheus.MustNewConstMetric(BatterySecondsEmpty, prometheus.GaugeValue, *batterySecondsRemaining, circuitId)
+		circuit := c.circuits.labels(d.CircuitId)
+		ch <- prometheus.MustNewConstMetric(PowerConsumed, prometheus.GaugeValue, d.PowerConsumed, circuit...)
+		ch <- prometheus.MustNewConstMetric(PowerCapacity, prometheus.GaugeValue, d.PowerCapacity, circuit...)
+		ch <- prometheus.MustNewConstMetric(PowerMaxConsumed, prometheus.GaugeValue, d.PowerMaxConsumed, circuit...)
+		ch <- prometheus.MustNewConstMetric(BatteryDifferential, prometheus.GaugeValue, d.BatteryDifferential, circuit...)
+		ch <- prometheus.MustNewConstMetric(BatteryPercent, prometheus.GaugeValue, d.BatteryPercent, circuit...)
+		ch <- prometheus.MustNewConstMetric(BatteryCapacity, prometheus.GaugeValue, d.BatteryCapacity, circuit...)
+		if batterySecondsEmpty, ok := parseDurationSeconds(d.BatteryTimeEmpty); ok {
+			ch <- prometheus.MustNewConstMetric(BatterySecondsEmpty, prometheus.GaugeValue, batterySecondsEmpty, circuit...)
 		}
-		batterySecondsFull := parseTimeSeconds(d.BatteryTimeFull)
-		if batterySecondsFull != nil {
-			ch <- prometheus.MustNewConstMetric(BatterySecondsFull, prometheus.GaugeValue, *batterySecondsFull, circuitId)
+		if batterySecondsFull, ok := parseDurationSeconds(d.BatteryTimeFull); ok {
+			ch <- prometheus.MustNewConstMetric(BatterySecondsFull, prometheus.GaugeValue, batterySecondsFull, circuit...)
 		}
-		ch <- prometheus.MustNewConstMetric(FuseTriggered, prometheus.GaugeValue, parseBool(d.FuseTriggered), circuitId)
+		ch <- prometheus.MustNewConstMetric(FuseTriggered, prometheus.GaugeValue, parseBool(d.FuseTriggered), circuit...)
 	}
+	return nil
 }
@@ -0,0 +1,140 @@
+package exporter
+
+import (
+	"sync"
+	"time"
+
+	"github.com/justereseau/satisfactory-metrics/satisfactory-exporter/frm"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Duration of the history the power trends are computed on.
+var PowerForecastWindow = 10 * time.Minute
+
+// PowerForecaster keeps a short history of the power circuits to predict when
+// their fuse will trip.
+//
+// A circuit consuming more than its capacity runs on its batteries, the fuse
+// trips when they are empty. Otherwise, the fuse trips when the consumption,
+// following its trend over PowerForecastWindow, reaches the capacity. The
+// batteries that would then be drained are not accounted for.
+type PowerForecaster struct {
+	mu       sync.Mutex
+	circuits map[float64][]powerSample
+	observed time.Time // time of the last observation
+}
+
+type powerSample struct {
+	time           time.Time
+	consumed       float64
+	capacity       float64
+	batteryPercent float64
+	batteryEmpty   string
+	fuseTriggered  bool
+}
+
+func NewPowerForecaster() *PowerForecaster {
+	return &PowerForecaster{
+		circuits: map[float64][]powerSample{},
+	}
+}
+
+// Observe records the state of the circuits at the given time. Circuits that
+// are not part of the observation are forgotten.
+//
+// A snapshot seen again, by another scrape of the same poll, is ignored so that
+// it does not weigh twice in the trends.
+func (f *PowerForecaster) Observe(now time.Time, circuits []frm.PowerDetails) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if !now.After(f.observed) {
+		return
+	}
+	f.observed = now
+
+	seen := map[float64]bool{}
+	for _, d := range circuits {
+		seen[d.CircuitId] = true
+		samples := append(f.circuits[d.CircuitId], powerSample{
+			time:           now,
+			consumed:       d.PowerConsumed,
+			capacity:       d.PowerCapacity,
+			batteryPercent: d.BatteryPercent,
+			batteryEmpty:   d.BatteryTimeEmpty,
+			fuseTriggered:  d.FuseTriggered,
+		})
+		for len(samples) > 1 && now.Sub(samples[0].time) > PowerForecastWindow {
+			samples = samples[1:]
+		}
+		f.circuits[d.CircuitId] = samples
+	}
+
+	for circuitId := range f.circuits {
+		if !seen[circuitId] {
+			delete(f.circuits, circuitId)
+		}
+	}
+}
+
+// trend returns the slope per second of a value of the samples, computed by
+// linear regression, and false when the samples do not span any time.
+func trend(samples []powerSample, value func(powerSample) float64) (float64, bool) {
+	n := float64(len(samples))
+	sumT, sumV, sumTT, sumTV := 0.0, 0.0, 0.0, 0.0
+	for _, s := range samples {
+		t := s.time.Sub(samples[0].time).Seconds()
+		v := value(s)
+		sumT += t
+		sumV += v
+		sumTT += t * t
+		sumTV += t * v
+	}
+	denominator := n*sumTT - sumT*sumT
+	if denominator == 0 {
+		return 0, false
+	}
+	return (n*sumTV - sumT*sumV) / denominator, true
+}
+
+// fuseTripSeconds predicts the time until the fuse of the circuit trips, and
+// false when no trip is expected.
+func fuseTripSeconds(samples []powerSample) (float64, bool) {
+	last := samples[len(samples)-1]
+	if last.fuseTriggered {
+		return 0, true
+	}
+
+	if last.consumed > last.capacity {
+		if slope, ok := trend(samples, func(s powerSample) float64 { return s.batteryPercent }); ok && slope < 0 {
+			return last.batteryPercent / -slope, true
+		}
+		if seconds, ok := parseDurationSeconds(last.batteryEmpty); ok && seconds > 0 {
+			return seconds, true
+		}
+		return 0, false
+	}
+
+	slope, ok := trend(samples, func(s powerSample) float64 { return s.consumed })
+	if !ok || slope <= 0 {
+		return 0, false
+	}
+	return (last.capacity - last.consumed) / slope, true
+}
+
+func (f *PowerForecaster) collect(ch chan<- prometheus.Metric, circuits *CircuitTracker) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for circuitId, samples := range f.circuits {
+		circuit := circuits.labels(circuitId)
+		last := samples[len(samples)-1]
+		ch <- prometheus.MustNewConstMetric(PowerHeadroom, prometheus.GaugeValue, last.capacity-last.consumed, circuit...)
+		if slope, ok := trend(samples, func(s powerSample) float64 { return s.consumed }); ok {
+			ch <- prometheus.MustNewConstMetric(PowerConsumedTrend, prometheus.GaugeValue, slope*60, circuit...)
+		}
+		if seconds, ok := fuseTripSeconds(samples); ok {
+			ch <- prometheus.MustNewConstMetric(PowerFuseTripSeconds, prometheus.GaugeValue, seconds, circuit...)
+		}
+	}
+}
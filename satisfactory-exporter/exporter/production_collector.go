@@ -1,41 +1,28 @@
 package exporter
 
 import (
+	"context"
 	"github.com/go-kit/log"
-	"github.com/go-kit/log/level"
+	"github.com/justereseau/satisfactory-metrics/satisfactory-exporter/frm"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
 type ProductionCollector struct {
-	frmTarget string
-	logger    log.Logger
+	source Source
+	logger log.Logger
 }
 
-type ProductionDetails struct {
-	ItemName           string  `json:"Name"`
-	ProdPercent        float64 `json:"ProdPercent"`
-	ConsPercent        float64 `json:"ConsPercent"`
-	CurrentProduction  float64 `json:"CurrentProd"`
-	CurrentConsumption float64 `json:"CurrentConsumed"`
-	MaxProd            float64 `json:"MaxProd"`
-	MaxConsumed        float64 `json:"MaxConsumed"`
-}
-
-func NewProductionCollector(frmApiAddress string, logger log.Logger) *ProductionCollector {
+func NewProductionCollector(source Source, logger log.Logger) *ProductionCollector {
 	return &ProductionCollector{
-		frmTarget: frmApiAddress + "/getProdStats",
-		logger:    logger,
+		source: source,
+		logger: logger,
 	}
 }
 
-func (c ProductionCollector) Describe(ch chan<- *prometheus.Desc) {}
-
-func (c *ProductionCollector) Collect(ch chan<- prometheus.Metric) {
-	details := []ProductionDetails{}
-	err := retrieveData(c.frmTarget, &details)
+func (c *ProductionCollector) Update(ctx context.Context, ch chan<- prometheus.Metric) error {
+	details, err := frm.Routes{Getter: c.source}.GetProdStats(ctx)
 	if err != nil {
-		level.Error(c.logger).Log("msg", "Error reading production statistics from Ficsit Metrics", "err", err)
-		return
+		return err
 	}
 
 	for _, d := range details {
@@ -46,4 +33,5 @@ func (c *ProductionCollector) Collect(ch chan<- prometheus.Metric) {
 		ch <- prometheus.MustNewConstMetric(ItemProductionCapacityPerMinute, prometheus.GaugeValue, d.MaxProd, d.ItemName)
 		ch <- prometheus.MustNewConstMetric(ItemConsumptionCapacityPerMinute, prometheus.GaugeValue, d.MaxConsumed, d.ItemName)
 	}
+	return nil
 }
@@ -8,16 +8,33 @@ type MetricVectorDetails struct {
 	Name   string
 	Help   string
 	Labels []string
+	Desc   *prometheus.Desc
 }
 
 var RegisteredMetricVectors = []MetricVectorDetails{}
 var RegisteredMetrics = []*prometheus.GaugeVec{}
 
 func RegisterNewGaugeVec(opts prometheus.GaugeOpts, labelNames []string) *prometheus.Desc {
-	return prometheus.NewDesc(
-		opts.Name,
-		opts.Help,
+	return registerNewVec(opts.Name, opts.Help, labelNames)
+}
+
+// RegisterNewCounterVec registers a metric whose series are counters.
+func RegisterNewCounterVec(opts prometheus.CounterOpts, labelNames []string) *prometheus.Desc {
+	return registerNewVec(opts.Name, opts.Help, labelNames)
+}
+
+func registerNewVec(name, help string, labelNames []string) *prometheus.Desc {
+	desc := prometheus.NewDesc(
+		name,
+		help,
 		labelNames,
 		nil,
 	)
+	RegisteredMetricVectors = append(RegisteredMetricVectors, MetricVectorDetails{
+		Name:   name,
+		Help:   help,
+		Labels: labelNames,
+		Desc:   desc,
+	})
+	return desc
 }
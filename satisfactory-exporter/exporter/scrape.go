@@ -0,0 +1,119 @@
+package exporter
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Collector is implemented by every FRM collector. Update sends the metrics
+// of the collector on ch and gives up once ctx is done.
+type Collector interface {
+	Update(ctx context.Context, ch chan<- prometheus.Metric) error
+}
+
+// ScrapeCollector runs a set of named collectors concurrently, each one under
+// its own deadline, and reports how long each of them took and whether it
+// succeeded.
+type ScrapeCollector struct {
+	ctx            context.Context
+	collectors     map[string]Collector
+	defaultTimeout time.Duration
+	timeouts       map[string]time.Duration
+	policy         *LabelPolicy
+	logger         log.Logger
+}
+
+// NewScrapeCollector creates a ScrapeCollector for a single scrape. A timeout
+// of 0 means the collector is only bound by ctx. The metrics of the collectors
+// are rewritten by policy, which may be nil.
+func NewScrapeCollector(ctx context.Context, collectors map[string]Collector, defaultTimeout time.Duration, timeouts map[string]time.Duration, policy *LabelPolicy, logger log.Logger) *ScrapeCollector {
+	return &ScrapeCollector{
+		ctx:            ctx,
+		collectors:     collectors,
+		defaultTimeout: defaultTimeout,
+		timeouts:       timeouts,
+		policy:         policy,
+		logger:         logger,
+	}
+}
+
+func (s ScrapeCollector) Describe(ch chan<- *prometheus.Desc) {}
+
+func (s *ScrapeCollector) Collect(ch chan<- prometheus.Metric) {
+	wg := sync.WaitGroup{}
+	wg.Add(len(s.collectors))
+	for name, c := range s.collectors {
+		go func(name string, c Collector) {
+			defer wg.Done()
+			s.execute(name, c, ch)
+		}(name, c)
+	}
+	wg.Wait()
+}
+
+func (s *ScrapeCollector) timeout(name string) time.Duration {
+	if timeout, ok := s.timeouts[name]; ok {
+		return timeout
+	}
+	return s.defaultTimeout
+}
+
+func (s *ScrapeCollector) execute(name string, c Collector, ch chan<- prometheus.Metric) {
+	ctx := s.ctx
+	if timeout := s.timeout(name); timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	begin := time.Now()
+	metrics, err := s.update(ctx, c)
+	duration := time.Since(begin)
+
+	success := 0.0
+	if err != nil {
+		level.Error(s.logger).Log("msg", "Collector failed", "collector", name, "duration_seconds", duration.Seconds(), "err", err)
+	} else {
+		level.Debug(s.logger).Log("msg", "Collector succeeded", "collector", name, "duration_seconds", duration.Seconds())
+		success = 1
+		for _, m := range s.policy.Apply(metrics) {
+			ch <- m
+		}
+	}
+	ch <- prometheus.MustNewConstMetric(ScrapeCollectorDuration, prometheus.GaugeValue, duration.Seconds(), name)
+	ch <- prometheus.MustNewConstMetric(ScrapeCollectorSuccess, prometheus.GaugeValue, success, name)
+}
+
+// update buffers the metrics of the collector so that nothing is sent to the
+// registry once the deadline is exceeded, even if the collector keeps running.
+func (s *ScrapeCollector) update(ctx context.Context, c Collector) ([]prometheus.Metric, error) {
+	buf := make(chan prometheus.Metric)
+	result := make(chan error, 1)
+	go func() {
+		defer close(buf)
+		result <- c.Update(ctx, buf)
+	}()
+
+	metrics := []prometheus.Metric{}
+	for {
+		select {
+		case m, ok := <-buf:
+			if !ok {
+				return metrics, <-result
+			}
+			metrics = append(metrics, m)
+		case <-ctx.Done():
+			// Let the collector finish on its own, its metrics are dropped.
+			go func() {
+				for range buf {
+				}
+			}()
+			return nil, ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package exporter
+
+import (
+	"sync"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// UnknownState is the state of a state set for the values that are not one of
+// its states.
+const UnknownState = "unknown"
+
+// Minimum time between two logs of the same unknown value of a state set.
+var StateLogInterval = 10 * time.Minute
+
+// StateSet is a metric with one series per state, labeled by state, in which
+// the current state is 1 and the others are 0, like an OpenMetrics StateSet.
+type StateSet struct {
+	name   string
+	desc   *prometheus.Desc
+	states []string
+	mu     sync.Mutex
+	logged map[string]time.Time
+}
+
+// RegisterNewStateSet registers a state set metric, its series have the
+// labelNames followed by a state label. A value that is not one of states is
+// reported as UnknownState.
+func RegisterNewStateSet(opts prometheus.GaugeOpts, labelNames []string, states ...string) *StateSet {
+	return &StateSet{
+		name:   opts.Name,
+		desc:   RegisterNewGaugeVec(opts, append(labelNames, "state")),
+		states: append(states, UnknownState),
+		logged: map[string]time.Time{},
+	}
+}
+
+// collect sends the series of the state set with value as the current state.
+func (s *StateSet) collect(ch chan<- prometheus.Metric, logger log.Logger, value string, labelValues ...string) {
+	current := UnknownState
+	for _, state := range s.states[:len(s.states)-1] {
+		if state == value {
+			current = value
+		}
+	}
+	if current == UnknownState {
+		s.logUnknown(logger, value)
+	}
+
+	for _, state := range s.states {
+		v := 0.0
+		if state == current {
+			v = 1
+		}
+		ch <- prometheus.MustNewConstMetric(s.desc, prometheus.GaugeValue, v, append(labelValues, state)...)
+	}
+}
+
+// logUnknown logs an unknown value, at most once per StateLogInterval.
+func (s *StateSet) logUnknown(logger log.Logger, value string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if last, ok := s.logged[value]; ok && now.Sub(last) < StateLogInterval {
+		return
+	}
+	s.logged[value] = now
+	level.Warn(logger).Log("msg", "Unknown state", "metric", s.name, "value", value)
+}
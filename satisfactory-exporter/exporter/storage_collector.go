@@ -0,0 +1,61 @@
+package exporter
+
+import (
+	"context"
+
+	"github.com/go-kit/log"
+	"github.com/justereseau/satisfactory-metrics/satisfactory-exporter/frm"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type StorageCollector struct {
+	source       Source
+	perContainer bool
+	logger       log.Logger
+}
+
+// NewStorageCollector creates a collector for the storage containers. Metrics
+// per container are only sent when perContainer is set, as there is one
+// series per container and item.
+func NewStorageCollector(source Source, perContainer bool, logger log.Logger) *StorageCollector {
+	return &StorageCollector{
+		source:       source,
+		perContainer: perContainer,
+		logger:       logger,
+	}
+}
+
+func (c *StorageCollector) Update(ctx context.Context, ch chan<- prometheus.Metric) error {
+	details, err := frm.Routes{Getter: c.source}.GetStorageInv(ctx)
+	if err != nil {
+		return err
+	}
+
+	items := map[string]float64{}
+	for _, d := range details {
+		amount := 0.0
+		maxAmount := 0.0
+		for _, item := range d.Inventory {
+			items[item.Name] += item.Amount
+			amount += item.Amount
+			maxAmount += item.MaxAmount
+		}
+
+		if !c.perContainer {
+			continue
+		}
+		gh, xs, ys, zs := locationLabels(d.Location)
+
+		for _, item := range d.Inventory {
+			ch <- prometheus.MustNewConstMetric(StorageContainerItems, prometheus.GaugeValue, item.Amount, d.Id, d.Name, item.Name, gh, xs, ys, zs)
+		}
+		if maxAmount > 0 {
+			ch <- prometheus.MustNewConstMetric(StorageContainerFill, prometheus.GaugeValue, amount/maxAmount*100, d.Id, d.Name, gh, xs, ys, zs)
+		}
+	}
+
+	for name, amount := range items {
+		ch <- prometheus.MustNewConstMetric(StorageItems, prometheus.GaugeValue, amount, name)
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package exporter
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	WorldInventoryItems = RegisterNewGaugeVec(prometheus.GaugeOpts{
+		Name: "world_inventory_items",
+		Help: "Number of an item stored in the world, containers and buildings inventories included",
+	}, []string{
+		"item_name",
+	})
+	StorageItems = RegisterNewGaugeVec(prometheus.GaugeOpts{
+		Name: "storage_items",
+		Help: "Number of an item stored in all the storage containers",
+	}, []string{
+		"item_name",
+	})
+	StorageContainerItems = RegisterNewGaugeVec(prometheus.GaugeOpts{
+		Name: "storage_container_items",
+		Help: "Number of an item stored in a storage container",
+	}, []string{
+		"id",
+		"container_type",
+		"item_name",
+		"geohash",
+		"x",
+		"y",
+		"z",
+	})
+	StorageContainerFill = RegisterNewGaugeVec(prometheus.GaugeOpts{
+		Name: "storage_container_fill_pc",
+		Help: "Fill level of a storage container, in percent",
+	}, []string{
+		"id",
+		"container_type",
+		"geohash",
+		"x",
+		"y",
+		"z",
+	})
+)
@@ -0,0 +1,42 @@
+package exporter
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/justereseau/satisfactory-metrics/satisfactory-exporter/frm"
+)
+
+// Target is a FRM webserver monitored by the exporter.
+type Target struct {
+	Name   string
+	Source Source
+	// Poller is nil when the webserver is queried on every scrape.
+	Poller    *Poller
+	Trains    *TrainTracker
+	Platforms *PlatformTracker
+	Vehicles  *VehicleTracker
+	Power     *PowerForecaster
+	Circuits  *CircuitTracker
+}
+
+// NewTarget creates a target for the webserver behind client. A pollInterval
+// of 0 disables the background polling, circuitNames are the configured names
+// of the power circuits.
+func NewTarget(ctx context.Context, name string, client *frm.Client, pollInterval time.Duration, pollIntervals map[string]time.Duration, circuitNames map[float64]string, logger log.Logger) *Target {
+	t := &Target{
+		Name:      name,
+		Source:    client,
+		Trains:    NewTrainTracker(),
+		Platforms: NewPlatformTracker(),
+		Vehicles:  NewVehicleTracker(),
+		Power:     NewPowerForecaster(),
+		Circuits:  NewCircuitTracker(circuitNames),
+	}
+	if pollInterval > 0 {
+		t.Poller = NewPoller(ctx, client, pollInterval, pollIntervals, logger)
+		t.Source = t.Poller
+	}
+	return t
+}
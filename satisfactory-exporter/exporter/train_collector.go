@@ -1,68 +1,79 @@
 package exporter
 
 import (
+	"context"
 	"strconv"
+	"strings"
 
 	"github.com/go-kit/log"
-	"github.com/go-kit/log/level"
+	"github.com/justereseau/satisfactory-metrics/satisfactory-exporter/frm"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
-var MaxTrainPowerConsumption = 110.0
+// Types of train cars.
+var trainCarTypes = []string{"locomotive", "freight", "fluid", "other"}
 
-type TrainCollector struct {
-	frmTarget string
-	logger    log.Logger
-}
-
-type TrainCar struct {
-	Name           string  `json:"Name"`
-	TotalMass      float64 `json:"TotalMass"`
-	PayloadMass    float64 `json:"PayloadMass"`
-	MaxPayloadMass float64 `json:"MaxPayloadMass"`
+// trainCarType returns the type of a train car from its name.
+func trainCarType(name string) string {
+	switch {
+	case strings.Contains(name, "Locomotive"):
+		return "locomotive"
+	case strings.Contains(name, "Fluid"):
+		return "fluid"
+	case strings.Contains(name, "Freight"):
+		return "freight"
+	default:
+		return "other"
+	}
 }
 
-type TrainDetails struct {
-	TrainName       string     `json:"Name"`
-	PowerConsumed   float64    `json:"PowerConsumed"`
-	TrainStation    string     `json:"TrainStation"`
-	Derailed        bool       `json:"Derailed"`
-	Status          string     `json:"Status"` //"Self-Driving",
-	TrainConsist    []TrainCar `json:"TrainConsist"`
-	ForwardSpeed    float64    `json:"ForwardSpeed"`
-	ThrottlePercent float64    `json:"ThrottlePercent"`
-	TotalMass       float64    `json:"TotalMass"`
-	PayloadMass     float64    `json:"PayloadMass"`
-	MaxPayloadMass  float64    `json:"MaxPayloadMass"`
+type TrainCollector struct {
+	source   Source
+	tracker  *TrainTracker
+	circuits *CircuitTracker
+	logger   log.Logger
 }
 
-func NewTrainCollector(frmApiAddress string, logger log.Logger) *TrainCollector {
+func NewTrainCollector(source Source, tracker *TrainTracker, circuits *CircuitTracker, logger log.Logger) *TrainCollector {
 	return &TrainCollector{
-		frmTarget: frmApiAddress + "/getTrains",
-		logger:    logger,
+		source:   source,
+		tracker:  tracker,
+		circuits: circuits,
+		logger:   logger,
 	}
 }
 
-func (c TrainCollector) Describe(ch chan<- *prometheus.Desc) {}
-
-func (c *TrainCollector) Collect(ch chan<- prometheus.Metric) {
-	details := []TrainDetails{}
-	err := retrieveData(c.frmTarget, &details)
+func (c *TrainCollector) Update(ctx context.Context, ch chan<- prometheus.Metric) error {
+	source := &observedSource{Source: c.source}
+	details, err := frm.Routes{Getter: source}.GetTrains(ctx)
 	if err != nil {
-		level.Error(c.logger).Log("msg", "Error reading trains statistics from Ficsit Metrics", "err", err)
-		return
+		return err
 	}
 
+	c.tracker.Observe(source.observed, details)
+	c.tracker.collect(ch)
+
+	locomotivePower, _ := Game.Power("Electric Locomotive")
 	powerInfo := map[float64]float64{}
 	maxPowerInfo := map[float64]float64{}
 	for _, d := range details {
-		locomotives := 0.0
+		cars := map[string]float64{}
+		for n, car := range d.TrainConsist {
+			carType := trainCarType(car.Name)
+			cars[carType]++
 
-		for _, car := range d.TrainConsist {
-			if car.Name == "Electric Locomotive" {
-				locomotives = locomotives + 1
+			position := strconv.Itoa(n)
+			ch <- prometheus.MustNewConstMetric(TrainCarTotalMass, prometheus.GaugeValue, car.TotalMass, d.TrainName, position, carType)
+			ch <- prometheus.MustNewConstMetric(TrainCarPayloadMass, prometheus.GaugeValue, car.PayloadMass, d.TrainName, position, carType)
+			ch <- prometheus.MustNewConstMetric(TrainCarMaxPayloadMass, prometheus.GaugeValue, car.MaxPayloadMass, d.TrainName, position, carType)
+			if car.MaxPayloadMass > 0 {
+				ch <- prometheus.MustNewConstMetric(TrainCarFill, prometheus.GaugeValue, car.PayloadMass/car.MaxPayloadMass, d.TrainName, position, carType)
 			}
 		}
+		for _, carType := range trainCarTypes {
+			ch <- prometheus.MustNewConstMetric(TrainCars, prometheus.GaugeValue, cars[carType], d.TrainName, carType)
+		}
+		locomotives := cars["locomotive"]
 
 		// for now, the total power consumed is a multiple of the reported power consumed by the number of locomotives
 		trainPowerConsumed := d.PowerConsumed * locomotives
@@ -76,23 +87,16 @@ func (c *TrainCollector) Collect(ch chan<- prometheus.Metric) {
 		ch <- prometheus.MustNewConstMetric(TrainThrottlePercent, prometheus.GaugeValue, d.ThrottlePercent, d.TrainName)
 		ch <- prometheus.MustNewConstMetric(TrainLocomotives, prometheus.GaugeValue, locomotives, d.TrainName)
 
-		switch d.Status {
-		case "Parked":
-			ch <- prometheus.MustNewConstMetric(TrainDrivingStatus, prometheus.GaugeValue, 0, d.TrainName)
-		case "Manual Driving":
-			ch <- prometheus.MustNewConstMetric(TrainDrivingStatus, prometheus.GaugeValue, 1, d.TrainName)
-		case "Self-Driving":
-			ch <- prometheus.MustNewConstMetric(TrainDrivingStatus, prometheus.GaugeValue, 2, d.TrainName)
-		default:
-			ch <- prometheus.MustNewConstMetric(TrainDrivingStatus, prometheus.GaugeValue, -1, d.TrainName)
-			c.logger.Log("msg", "Unknown train status", "status", d.Status)
-		}
+		powerInfo[d.PowerInfo.CircuitId] += trainPowerConsumed
+		maxPowerInfo[d.PowerInfo.CircuitId] += locomotivePower * locomotives
 
+		TrainDrivingStatus.collect(ch, c.logger, d.Status, d.TrainName)
 	}
 	for circuitId, powerConsumed := range powerInfo {
-		ch <- prometheus.MustNewConstMetric(TrainCircuitPower, prometheus.GaugeValue, powerConsumed, strconv.FormatFloat(circuitId, 'f', -1, 64))
+		ch <- prometheus.MustNewConstMetric(TrainCircuitPower, prometheus.GaugeValue, powerConsumed, c.circuits.labels(circuitId)...)
 	}
 	for circuitId, powerConsumed := range maxPowerInfo {
-		ch <- prometheus.MustNewConstMetric(TrainCircuitPowerMax, prometheus.GaugeValue, powerConsumed, strconv.FormatFloat(circuitId, 'f', -1, 64))
+		ch <- prometheus.MustNewConstMetric(TrainCircuitPowerMax, prometheus.GaugeValue, powerConsumed, c.circuits.labels(circuitId)...)
 	}
+	return nil
 }
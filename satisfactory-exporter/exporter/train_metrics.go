@@ -0,0 +1,47 @@
+package exporter
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	TrainCarTotalMass = RegisterNewGaugeVec(prometheus.GaugeOpts{
+		Name: "train_car_total_mass",
+		Help: "Total mass of a car of a train, by position from the front of the train",
+	}, []string{
+		"name",
+		"position",
+		"car_type",
+	})
+	TrainCarPayloadMass = RegisterNewGaugeVec(prometheus.GaugeOpts{
+		Name: "train_car_payload_mass",
+		Help: "Payload mass of a car of a train, by position from the front of the train",
+	}, []string{
+		"name",
+		"position",
+		"car_type",
+	})
+	TrainCarMaxPayloadMass = RegisterNewGaugeVec(prometheus.GaugeOpts{
+		Name: "train_car_max_payload_mass",
+		Help: "Max payload mass of a car of a train, by position from the front of the train",
+	}, []string{
+		"name",
+		"position",
+		"car_type",
+	})
+	TrainCarFill = RegisterNewGaugeVec(prometheus.GaugeOpts{
+		Name: "train_car_fill_ratio",
+		Help: "Payload mass of a car of a train over its max payload mass, between 0 and 1",
+	}, []string{
+		"name",
+		"position",
+		"car_type",
+	})
+	TrainCars = RegisterNewGaugeVec(prometheus.GaugeOpts{
+		Name: "train_cars",
+		Help: "Number of cars of a train, per car type: locomotive, freight, fluid or other",
+	}, []string{
+		"name",
+		"car_type",
+	})
+)
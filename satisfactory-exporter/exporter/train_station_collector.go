@@ -1,70 +1,75 @@
 package exporter
 
 import (
-	"strconv"
+	"context"
+	"time"
 
 	"github.com/go-kit/log"
-	"github.com/go-kit/log/level"
+	"github.com/justereseau/satisfactory-metrics/satisfactory-exporter/frm"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
-var (
-	StationPower       = 0.1 // should be 50, but currently bugged.
-	CargoPlatformPower = 50.0
-)
-
 type TrainStationCollector struct {
-	frmTarget string
+	source    Source
+	platforms *PlatformTracker
+	circuits  *CircuitTracker
 	logger    log.Logger
 }
 
-type CargoPlatform struct {
-	LoadingDock   string  `json:"LoadingDock"`
-	TransferRate  float64 `json:"TransferRate"`
-	LoadingStatus string  `json:"LoadingStatus"` // Idle, Loading, Unloading
-	LoadingMode   string  `json:"LoadingMode"`
-}
-
-type TrainStationDetails struct {
-	Name           string          `json:"Name"`
-	Location       Location        `json:"location"`
-	CargoPlatforms []CargoPlatform `json:"CargoPlatforms"`
-	PowerInfo      PowerInfo       `json:"PowerInfo"`
-}
-
-func NewTrainStationCollector(frmApiAddress string, logger log.Logger) *TrainStationCollector {
+func NewTrainStationCollector(source Source, platforms *PlatformTracker, circuits *CircuitTracker, logger log.Logger) *TrainStationCollector {
 	return &TrainStationCollector{
-		frmTarget: frmApiAddress + "/getTrainStation",
+		source:    source,
+		platforms: platforms,
+		circuits:  circuits,
 		logger:    logger,
 	}
 }
 
-func (c TrainStationCollector) Describe(ch chan<- *prometheus.Desc) {}
-
-func (c *TrainStationCollector) Collect(ch chan<- prometheus.Metric) {
-	details := []TrainStationDetails{}
-	err := retrieveData(c.frmTarget, &details)
+func (c *TrainStationCollector) Update(ctx context.Context, ch chan<- prometheus.Metric) error {
+	source := &observedSource{Source: c.source}
+	details, err := frm.Routes{Getter: source}.GetTrainStation(ctx)
 	if err != nil {
-		level.Error(c.logger).Log("msg", "Error reading train station statistics from Ficsit Metrics", "err", err)
-		return
+		return err
 	}
 
+	c.platforms.Observe(source.observed, details)
+	c.platforms.collect(time.Now(), ch)
+
 	powerInfo := map[float64]float64{}
 	maxPowerInfo := map[float64]float64{}
 	for _, d := range details {
+		for _, p := range d.CargoPlatforms {
+			TrainPlatformLoadingStatus.collect(ch, c.logger, p.LoadingStatus, p.Id, d.Id, d.Name)
+			TrainPlatformLoadingMode.collect(ch, c.logger, p.LoadingMode, p.Id, d.Id, d.Name)
+
+			ch <- prometheus.MustNewConstMetric(TrainPlatformTransferRate, prometheus.GaugeValue, p.TransferRate, p.Id, d.Id, d.Name)
+
+			amount, maxAmount := 0.0, 0.0
+			for _, item := range p.Inventory {
+				amount += item.Amount
+				maxAmount += item.MaxAmount
+			}
+			if maxAmount > 0 {
+				ch <- prometheus.MustNewConstMetric(TrainPlatformFill, prometheus.GaugeValue, amount/maxAmount, p.Id, d.Id, d.Name)
+			}
+		}
+
 		val, ok := powerInfo[d.PowerInfo.CircuitId]
 		maxval, maxok := maxPowerInfo[d.PowerInfo.CircuitId]
 
 		// some additional calculations: for now, power listed here is only for the station.
-		// add each of the cargo platforms' power info: 0.1MW if Idle, 50MW otherwise
+		// add each of the cargo platforms' power info: their idle power if Idle, their full power otherwise
+		stationPower, _ := Game.Power("Train Station")
+		platformPower, _ := Game.Power("Freight Platform")
+		platformIdlePower := Game.IdlePower("Freight Platform")
 		totalPowerConsumed := d.PowerInfo.PowerConsumed
-		maxTotalPowerConsumed := StationPower
+		maxTotalPowerConsumed := stationPower
 		for _, p := range d.CargoPlatforms {
-			maxTotalPowerConsumed = maxTotalPowerConsumed + CargoPlatformPower
+			maxTotalPowerConsumed = maxTotalPowerConsumed + platformPower
 			if p.LoadingStatus == "Idle" {
-				totalPowerConsumed = totalPowerConsumed + 0.1
+				totalPowerConsumed = totalPowerConsumed + platformIdlePower
 			} else {
-				totalPowerConsumed = totalPowerConsumed + CargoPlatformPower
+				totalPowerConsumed = totalPowerConsumed + platformPower
 			}
 		}
 
@@ -82,11 +87,12 @@ func (c *TrainStationCollector) Collect(ch chan<- prometheus.Metric) {
 	}
 
 	for circuitId, powerConsumed := range powerInfo {
-		ch <- prometheus.MustNewConstMetric(TrainStationPower, prometheus.GaugeValue, powerConsumed, strconv.FormatFloat(circuitId, 'f', -1, 64))
+		ch <- prometheus.MustNewConstMetric(TrainStationPower, prometheus.GaugeValue, powerConsumed, c.circuits.labels(circuitId)...)
 	}
 
 	for circuitId, powerConsumed := range maxPowerInfo {
-		ch <- prometheus.MustNewConstMetric(TrainStationPowerMax, prometheus.GaugeValue, powerConsumed, strconv.FormatFloat(circuitId, 'f', -1, 64))
+		ch <- prometheus.MustNewConstMetric(TrainStationPowerMax, prometheus.GaugeValue, powerConsumed, c.circuits.labels(circuitId)...)
 
 	}
+	return nil
 }
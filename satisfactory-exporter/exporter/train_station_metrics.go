@@ -0,0 +1,72 @@
+package exporter
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	TrainPlatformLoadingStatus = RegisterNewStateSet(prometheus.GaugeOpts{
+		Name: "train_station_platform_loading_status",
+		Help: "Loading status of a cargo platform, 1 for the current status and 0 for the others",
+	}, []string{
+		"id",
+		"station_id",
+		"station_name",
+	}, "Idle", "Loading", "Unloading")
+	TrainPlatformLoadingMode = RegisterNewStateSet(prometheus.GaugeOpts{
+		Name: "train_station_platform_loading_mode",
+		Help: "Loading mode of a cargo platform, 1 for the current mode and 0 for the others",
+	}, []string{
+		"id",
+		"station_id",
+		"station_name",
+	}, "Loading", "Unloading")
+	TrainPlatformTransferRate = RegisterNewGaugeVec(prometheus.GaugeOpts{
+		Name: "train_station_platform_transfer_rate",
+		Help: "Current transfer rate of a cargo platform",
+	}, []string{
+		"id",
+		"station_id",
+		"station_name",
+	})
+	TrainPlatformFill = RegisterNewGaugeVec(prometheus.GaugeOpts{
+		Name: "train_station_platform_fill_ratio",
+		Help: "Fill ratio of the inventory of a cargo platform, between 0 and 1",
+	}, []string{
+		"id",
+		"station_id",
+		"station_name",
+	})
+	TrainPlatformDocked = RegisterNewGaugeVec(prometheus.GaugeOpts{
+		Name: "train_station_platform_docked_seconds",
+		Help: "Time the train at a cargo platform has been loading or unloading, 0 when the platform is idle",
+	}, []string{
+		"id",
+		"station_id",
+		"station_name",
+	})
+	TrainPlatformDockedTotal = RegisterNewGaugeVec(prometheus.GaugeOpts{
+		Name: "train_station_platform_docked_seconds_total",
+		Help: "Total time trains spent loading or unloading at a cargo platform since the exporter started",
+	}, []string{
+		"id",
+		"station_id",
+		"station_name",
+	})
+	TrainPlatformDock = RegisterNewGaugeVec(prometheus.GaugeOpts{
+		Name: "train_station_platform_dock_seconds",
+		Help: "Time the last train spent loading or unloading at a cargo platform",
+	}, []string{
+		"id",
+		"station_id",
+		"station_name",
+	})
+	TrainPlatformDockAvg = RegisterNewGaugeVec(prometheus.GaugeOpts{
+		Name: "train_station_platform_dock_avg_seconds",
+		Help: "Average time the last trains spent loading or unloading at a cargo platform",
+	}, []string{
+		"id",
+		"station_id",
+		"station_name",
+	})
+)
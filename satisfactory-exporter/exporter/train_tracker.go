@@ -0,0 +1,151 @@
+package exporter
+
+import (
+	"sync"
+	"time"
+
+	"github.com/justereseau/satisfactory-metrics/satisfactory-exporter/frm"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Number of trips the average durations are computed on.
+var TrainTripHistory = 10
+
+// TrainTracker follows the trains across polls to time their trips.
+//
+// FRM only reports the station a train is heading to. A train departs a
+// station when that station changes, and is considered arrived the first time
+// it is seen stopped while heading to a station. A train waiting at a signal
+// is therefore seen as arrived, the durations are an approximation bounded by
+// the poll interval.
+type TrainTracker struct {
+	mu       sync.Mutex
+	trains   map[string]*trainState
+	observed time.Time // time of the last observation
+}
+
+type trainState struct {
+	station    string    // station the train is heading to
+	from       string    // station the train departed from, empty until a departure is seen
+	departed   time.Time // when the train departed from
+	arrived    time.Time // when the train stopped at station, zero while travelling
+	departures map[string]time.Time
+	segments   map[trainSegment]*tripDurations
+	roundTrip  tripDurations
+}
+
+type trainSegment struct {
+	from string
+	to   string
+}
+
+// tripDurations keeps the last durations of a trip.
+type tripDurations struct {
+	durations []time.Duration
+}
+
+func (t *tripDurations) add(d time.Duration) {
+	t.durations = append(t.durations, d)
+	if len(t.durations) > TrainTripHistory {
+		t.durations = t.durations[len(t.durations)-TrainTripHistory:]
+	}
+}
+
+func (t *tripDurations) latest() time.Duration {
+	return t.durations[len(t.durations)-1]
+}
+
+func (t *tripDurations) average() time.Duration {
+	total := time.Duration(0)
+	for _, d := range t.durations {
+		total += d
+	}
+	return total / time.Duration(len(t.durations))
+}
+
+func NewTrainTracker() *TrainTracker {
+	return &TrainTracker{
+		trains: map[string]*trainState{},
+	}
+}
+
+// Observe records the state of the trains at the given time. Trains that are
+// not part of the observation are forgotten.
+//
+// Observations that are not newer than the last one are ignored, a poll snapshot
+// served to several scrapes is observed once.
+func (t *TrainTracker) Observe(now time.Time, trains []frm.TrainDetails) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if !now.After(t.observed) {
+		return
+	}
+	t.observed = now
+
+	seen := map[string]bool{}
+	for _, d := range trains {
+		seen[d.TrainName] = true
+
+		state, ok := t.trains[d.TrainName]
+		if !ok {
+			t.trains[d.TrainName] = &trainState{
+				station:    d.TrainStation,
+				departures: map[string]time.Time{},
+				segments:   map[trainSegment]*tripDurations{},
+			}
+			continue
+		}
+
+		if d.TrainStation == state.station {
+			if state.from != "" && state.arrived.IsZero() && d.ForwardSpeed == 0 {
+				state.arrived = now
+			}
+			continue
+		}
+
+		// The train left state.station and is now heading to d.TrainStation.
+		if state.from != "" {
+			arrived := state.arrived
+			if arrived.IsZero() {
+				arrived = now
+			}
+			segment := trainSegment{from: state.from, to: state.station}
+			if _, ok := state.segments[segment]; !ok {
+				state.segments[segment] = &tripDurations{}
+			}
+			state.segments[segment].add(arrived.Sub(state.departed))
+		}
+		if last, ok := state.departures[state.station]; ok {
+			state.roundTrip.add(now.Sub(last))
+		}
+		state.departures[state.station] = now
+
+		state.from = state.station
+		state.station = d.TrainStation
+		state.departed = now
+		state.arrived = time.Time{}
+	}
+
+	for name := range t.trains {
+		if !seen[name] {
+			delete(t.trains, name)
+		}
+	}
+}
+
+func (t *TrainTracker) collect(ch chan<- prometheus.Metric) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for name, state := range t.trains {
+		if len(state.roundTrip.durations) > 0 {
+			ch <- prometheus.MustNewConstMetric(TrainRoundTrip, prometheus.GaugeValue, state.roundTrip.latest().Seconds(), name)
+			ch <- prometheus.MustNewConstMetric(TrainRoundTripAvg, prometheus.GaugeValue, state.roundTrip.average().Seconds(), name)
+		}
+		for segment, durations := range state.segments {
+			ch <- prometheus.MustNewConstMetric(TrainSegmentTrip, prometheus.GaugeValue, durations.latest().Seconds(), name, segment.from, segment.to)
+			ch <- prometheus.MustNewConstMetric(TrainSegmentTripAvg, prometheus.GaugeValue, durations.average().Seconds(), name, segment.from, segment.to)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package exporter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/justereseau/satisfactory-metrics/satisfactory-exporter/frm"
+)
+
+// trainSnapshot is the state of a single train polled at a time, in seconds.
+type trainSnapshot struct {
+	at      int
+	station string
+	speed   float64
+}
+
+func TestTrainTrackerObserve(t *testing.T) {
+	start := time.Unix(1000, 0)
+	at := func(seconds int) time.Time { return start.Add(time.Duration(seconds) * time.Second) }
+
+	tests := []struct {
+		name      string
+		snapshots []trainSnapshot
+		station   string
+		from      string
+		departed  time.Time
+		arrived   time.Time
+		segments  map[trainSegment]time.Duration
+		roundTrip time.Duration
+	}{{
+		name:      "first sighting",
+		snapshots: []trainSnapshot{{0, "A", 0}},
+		station:   "A",
+	}, {
+		name:      "departure",
+		snapshots: []trainSnapshot{{0, "A", 0}, {10, "B", 50}},
+		station:   "B",
+		from:      "A",
+		departed:  at(10),
+	}, {
+		name:      "no arrival without a departure",
+		snapshots: []trainSnapshot{{0, "A", 50}, {10, "A", 0}},
+		station:   "A",
+	}, {
+		name:      "arrival",
+		snapshots: []trainSnapshot{{0, "A", 0}, {10, "B", 50}, {20, "B", 50}, {30, "B", 0}, {40, "B", 0}},
+		station:   "B",
+		from:      "A",
+		departed:  at(10),
+		arrived:   at(30),
+	}, {
+		name:      "segment",
+		snapshots: []trainSnapshot{{0, "A", 0}, {10, "B", 50}, {40, "B", 0}, {50, "C", 50}},
+		station:   "C",
+		from:      "B",
+		departed:  at(50),
+		segments:  map[trainSegment]time.Duration{{from: "A", to: "B"}: 30 * time.Second},
+	}, {
+		name:      "segment without a stop",
+		snapshots: []trainSnapshot{{0, "A", 0}, {10, "B", 50}, {30, "C", 50}},
+		station:   "C",
+		from:      "B",
+		departed:  at(30),
+		segments:  map[trainSegment]time.Duration{{from: "A", to: "B"}: 20 * time.Second},
+	}, {
+		name:      "round trip",
+		snapshots: []trainSnapshot{{0, "A", 0}, {10, "B", 50}, {20, "A", 50}, {35, "B", 50}},
+		station:   "B",
+		from:      "A",
+		departed:  at(35),
+		segments: map[trainSegment]time.Duration{
+			{from: "A", to: "B"}: 10 * time.Second,
+			{from: "B", to: "A"}: 15 * time.Second,
+		},
+		roundTrip: 25 * time.Second,
+	}, {
+		name:      "repeated snapshot",
+		snapshots: []trainSnapshot{{0, "A", 0}, {10, "B", 50}, {10, "C", 50}, {5, "C", 50}},
+		station:   "B",
+		from:      "A",
+		departed:  at(10),
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tracker := NewTrainTracker()
+			for _, s := range test.snapshots {
+				tracker.Observe(at(s.at), []frm.TrainDetails{{TrainName: "Loop1", TrainStation: s.station, ForwardSpeed: s.speed}})
+			}
+
+			state := tracker.trains["Loop1"]
+			if state == nil {
+				t.Fatal("train not tracked")
+			}
+			if state.station != test.station || state.from != test.from {
+				t.Errorf("heading from %q to %q, want from %q to %q", state.from, state.station, test.from, test.station)
+			}
+			if !state.departed.Equal(test.departed) {
+				t.Errorf("departed at %v, want %v", state.departed, test.departed)
+			}
+			if !state.arrived.Equal(test.arrived) {
+				t.Errorf("arrived at %v, want %v", state.arrived, test.arrived)
+			}
+			if len(state.segments) != len(test.segments) {
+				t.Errorf("%d segments, want %d", len(state.segments), len(test.segments))
+			}
+			for segment, want := range test.segments {
+				durations, ok := state.segments[segment]
+				if !ok {
+					t.Errorf("segment %s to %s not timed", segment.from, segment.to)
+				} else if got := durations.latest(); got != want {
+					t.Errorf("segment %s to %s took %v, want %v", segment.from, segment.to, got, want)
+				}
+			}
+			switch {
+			case test.roundTrip == 0 && len(state.roundTrip.durations) > 0:
+				t.Errorf("round trip of %v, want none", state.roundTrip.latest())
+			case test.roundTrip != 0 && len(state.roundTrip.durations) == 0:
+				t.Errorf("no round trip, want %v", test.roundTrip)
+			case test.roundTrip != 0 && state.roundTrip.latest() != test.roundTrip:
+				t.Errorf("round trip of %v, want %v", state.roundTrip.latest(), test.roundTrip)
+			}
+		})
+	}
+}
+
+func TestTrainTrackerForgetsTrains(t *testing.T) {
+	tracker := NewTrainTracker()
+	tracker.Observe(time.Unix(1000, 0), []frm.TrainDetails{{TrainName: "Loop1"}, {TrainName: "Loop2"}})
+	tracker.Observe(time.Unix(1010, 0), []frm.TrainDetails{{TrainName: "Loop2"}})
+
+	if _, ok := tracker.trains["Loop1"]; ok {
+		t.Error("Loop1 is still tracked after disappearing")
+	}
+	if _, ok := tracker.trains["Loop2"]; !ok {
+		t.Error("Loop2 is not tracked anymore")
+	}
+}
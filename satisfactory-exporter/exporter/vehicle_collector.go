@@ -1,56 +1,57 @@
 package exporter
 
 import (
+	"context"
 	"strconv"
 	"time"
 
 	"github.com/go-kit/log"
 	"github.com/go-kit/log/level"
+	"github.com/justereseau/satisfactory-metrics/satisfactory-exporter/frm"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
 type VehicleCollector struct {
-	frmTarget string
-	logger    log.Logger
+	source  Source
+	tracker *VehicleTracker
+	logger  log.Logger
 }
 
-type VehicleDetails struct {
-	Id           string   `json:"ID"`
-	VehicleType  string   `json:"Name"`
-	Location     Location `json:"location"`
-	ForwardSpeed float64  `json:"ForwardSpeed"`
-	AutoPilot    bool     `json:"AutoPilot"`
-	Fuel         []Fuel   `json:"Fuel"`
-	PathName     string   `json:"PathName"`
-	DepartTime   time.Time
-	Departed     bool
-}
-
-type Fuel struct {
-	Name   string  `json:"Name"`
-	Amount float64 `json:"Amount"`
-}
-
-func NewVehicleCollector(frmApiAddress string, logger log.Logger) *VehicleCollector {
+func NewVehicleCollector(source Source, tracker *VehicleTracker, logger log.Logger) *VehicleCollector {
 	return &VehicleCollector{
-		frmTarget: frmApiAddress + "/getVehicles",
-		logger:    logger,
+		source:  source,
+		tracker: tracker,
+		logger:  logger,
 	}
 }
 
-func (c VehicleCollector) Describe(ch chan<- *prometheus.Desc) {}
-
-func (c *VehicleCollector) Collect(ch chan<- prometheus.Metric) {
-	details := []VehicleDetails{}
-	err := retrieveData(c.frmTarget, &details)
+func (c *VehicleCollector) Update(ctx context.Context, ch chan<- prometheus.Metric) error {
+	source := &observedSource{Source: c.source}
+	details, err := frm.Routes{Getter: source}.GetVehicles(ctx)
 	if err != nil {
-		level.Error(c.logger).Log("msg", "Error reading vehicle statistics from Ficsit Metrics", "err", err)
-		return
+		return err
 	}
 
 	for _, d := range details {
 		for n, f := range d.Fuel {
 			ch <- prometheus.MustNewConstMetric(VehicleFuel, prometheus.GaugeValue, f.Amount, d.Id, d.VehicleType, f.Name, strconv.Itoa(n))
 		}
+		ch <- prometheus.MustNewConstMetric(VehicleForwardSpeed, prometheus.GaugeValue, d.ForwardSpeed, d.Id, d.VehicleType)
+		ch <- prometheus.MustNewConstMetric(VehicleAutoPilot, prometheus.GaugeValue, parseBool(d.AutoPilot), d.Id, d.VehicleType)
+		p := project(d.Location)
+		ch <- prometheus.MustNewConstMetric(VehiclePosition, prometheus.GaugeValue, p.X, d.Id, d.VehicleType, "X")
+		ch <- prometheus.MustNewConstMetric(VehiclePosition, prometheus.GaugeValue, p.Y, d.Id, d.VehicleType, "Y")
+		ch <- prometheus.MustNewConstMetric(VehiclePosition, prometheus.GaugeValue, d.Location.Z, d.Id, d.VehicleType, "Z")
+	}
+
+	// Round trips are timed from the truck stations, without them only the
+	// trips already recorded are reported.
+	stations, err := frm.Routes{Getter: c.source}.GetTruckStation(ctx)
+	if err != nil {
+		level.Warn(c.logger).Log("msg", "Error reading vehicle stations, round trips are not updated", "err", err)
+	} else {
+		c.tracker.Observe(source.observed, details, stations)
 	}
+	c.tracker.collect(time.Now(), ch)
+	return nil
 }
@@ -1,43 +1,34 @@
 package exporter
 
 import (
-	"strconv"
+	"context"
 
 	"github.com/go-kit/log"
-	"github.com/go-kit/log/level"
+	"github.com/justereseau/satisfactory-metrics/satisfactory-exporter/frm"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
-var VehicleStationPowerConsumption = 20.0
-
 type VehicleStationCollector struct {
-	frmTarget string
-	logger    log.Logger
-}
-
-type VehicleStationDetails struct {
-	Name      string    `json:"Name"`
-	Location  Location  `json:"location"`
-	PowerInfo PowerInfo `json:"PowerInfo"`
+	source   Source
+	circuits *CircuitTracker
+	logger   log.Logger
 }
 
-func NewVehicleStationCollector(frmApiAddress string, logger log.Logger) *VehicleStationCollector {
+func NewVehicleStationCollector(source Source, circuits *CircuitTracker, logger log.Logger) *VehicleStationCollector {
 	return &VehicleStationCollector{
-		frmTarget: frmApiAddress + "/getTruckStation",
-		logger:    logger,
+		source:   source,
+		circuits: circuits,
+		logger:   logger,
 	}
 }
 
-func (c VehicleStationCollector) Describe(ch chan<- *prometheus.Desc) {}
-
-func (c *VehicleStationCollector) Collect(ch chan<- prometheus.Metric) {
-	details := []VehicleStationDetails{}
-	err := retrieveData(c.frmTarget, &details)
+func (c *VehicleStationCollector) Update(ctx context.Context, ch chan<- prometheus.Metric) error {
+	details, err := frm.Routes{Getter: c.source}.GetTruckStation(ctx)
 	if err != nil {
-		level.Error(c.logger).Log("msg", "Error reading vehicle station statistics from Ficsit Metrics", "err", err)
-		return
+		return err
 	}
 
+	stationPower, _ := Game.Power("Truck Station")
 	powerInfo := map[float64]float64{}
 	maxPowerInfo := map[float64]float64{}
 	for _, d := range details {
@@ -49,17 +40,18 @@ func (c *VehicleStationCollector) Collect(ch chan<- prometheus.Metric) {
 		}
 		val, ok = maxPowerInfo[d.PowerInfo.CircuitId]
 		if ok {
-			maxPowerInfo[d.PowerInfo.CircuitId] = val + VehicleStationPowerConsumption
+			maxPowerInfo[d.PowerInfo.CircuitId] = val + stationPower
 		} else {
-			maxPowerInfo[d.PowerInfo.CircuitId] = VehicleStationPowerConsumption
+			maxPowerInfo[d.PowerInfo.CircuitId] = stationPower
 		}
 	}
 
 	for circuitId, powerConsumed := range powerInfo {
-		ch <- prometheus.MustNewConstMetric(VehicleStationPower, prometheus.GaugeValue, powerConsumed, strconv.FormatFloat(circuitId, 'f', -1, 64))
+		ch <- prometheus.MustNewConstMetric(VehicleStationPower, prometheus.GaugeValue, powerConsumed, c.circuits.labels(circuitId)...)
 	}
 
 	for circuitId, powerConsumed := range maxPowerInfo {
-		ch <- prometheus.MustNewConstMetric(VehicleStationPowerMax, prometheus.GaugeValue, powerConsumed, strconv.FormatFloat(circuitId, 'f', -1, 64))
+		ch <- prometheus.MustNewConstMetric(VehicleStationPowerMax, prometheus.GaugeValue, powerConsumed, c.circuits.labels(circuitId)...)
 	}
+	return nil
 }
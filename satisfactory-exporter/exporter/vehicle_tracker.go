@@ -0,0 +1,108 @@
+package exporter
+
+import (
+	"sync"
+	"time"
+
+	"github.com/justereseau/satisfactory-metrics/satisfactory-exporter/frm"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// VehicleTracker follows the self-driving vehicles across polls to time
+// their round trips.
+//
+// A vehicle departs when it leaves the truck station it was seen at, and
+// completes its round trip once it is back at that station, facing the same
+// way as when it left.
+type VehicleTracker struct {
+	mu         sync.Mutex
+	vehicles   map[string]*trackedVehicle
+	observed   time.Time // time of the last observation
+	roundTrips map[string]float64
+}
+
+// trackedVehicle is the last state of a vehicle seen at a station. Location is
+// where the vehicle was when it was last at the station.
+type trackedVehicle struct {
+	frm.VehicleDetails
+	departTime time.Time
+	departed   bool
+}
+
+func NewVehicleTracker() *VehicleTracker {
+	return &VehicleTracker{
+		vehicles:   map[string]*trackedVehicle{},
+		roundTrips: map[string]float64{},
+	}
+}
+
+// Observe records the state of the vehicles at the given time. Vehicles that
+// are not part of the observation or are not self-driving are forgotten.
+// Observations that are not newer than the last one are ignored.
+func (t *VehicleTracker) Observe(now time.Time, vehicles []frm.VehicleDetails, stations []frm.VehicleStationDetails) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if !now.After(t.observed) {
+		return
+	}
+	t.observed = now
+
+	seen := map[string]bool{}
+	for _, d := range vehicles {
+		if !d.AutoPilot {
+			continue
+		}
+		seen[d.Id] = true
+
+		atStation := false
+		for _, s := range stations {
+			if d.Location.IsNearby(s.Location) {
+				atStation = true
+				break
+			}
+		}
+
+		tracked, exists := t.vehicles[d.Id]
+		if exists {
+			tracked.PathName = d.PathName
+		}
+		switch {
+		case !exists:
+			// Only start tracking once the vehicle is at a station.
+			if atStation {
+				t.vehicles[d.Id] = &trackedVehicle{VehicleDetails: d}
+			}
+		case !tracked.departed && atStation:
+			tracked.Location = d.Location
+		case !tracked.departed:
+			tracked.departed = true
+			tracked.departTime = now
+		case atStation && d.Location.IsNearby(tracked.Location) && d.Location.IsSameDirection(tracked.Location):
+			t.roundTrips[d.Id] = now.Sub(tracked.departTime).Seconds()
+			tracked.departed = false
+			tracked.Location = d.Location
+		}
+	}
+
+	for id := range t.vehicles {
+		if !seen[id] {
+			delete(t.vehicles, id)
+			delete(t.roundTrips, id)
+		}
+	}
+}
+
+func (t *VehicleTracker) collect(now time.Time, ch chan<- prometheus.Metric) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for id, vehicle := range t.vehicles {
+		if seconds, ok := t.roundTrips[id]; ok {
+			ch <- prometheus.MustNewConstMetric(VehicleRoundTrip, prometheus.GaugeValue, seconds, id, vehicle.VehicleType, vehicle.PathName)
+		}
+		if vehicle.departed {
+			ch <- prometheus.MustNewConstMetric(VehicleTripElapsed, prometheus.GaugeValue, now.Sub(vehicle.departTime).Seconds(), id, vehicle.VehicleType, vehicle.PathName)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package exporter
+
+import (
+	"context"
+
+	"github.com/go-kit/log"
+	"github.com/justereseau/satisfactory-metrics/satisfactory-exporter/frm"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type WorldInventoryCollector struct {
+	source Source
+	logger log.Logger
+}
+
+func NewWorldInventoryCollector(source Source, logger log.Logger) *WorldInventoryCollector {
+	return &WorldInventoryCollector{
+		source: source,
+		logger: logger,
+	}
+}
+
+func (c *WorldInventoryCollector) Update(ctx context.Context, ch chan<- prometheus.Metric) error {
+	details, err := frm.Routes{Getter: c.source}.GetWorldInv(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, d := range details {
+		ch <- prometheus.MustNewConstMetric(WorldInventoryItems, prometheus.GaugeValue, d.Amount, d.Name)
+	}
+	return nil
+}
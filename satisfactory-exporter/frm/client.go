@@ -0,0 +1,144 @@
+// Package frm is a client for the webserver of the Ficsit Remote Monitoring
+// mod.
+package frm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Client queries the FRM webserver. It is safe for concurrent use, and has
+// the typed methods of Routes.
+type Client struct {
+	Routes
+	baseURL    string
+	httpClient *http.Client
+	retries    int
+	backoff    time.Duration
+}
+
+type Option func(*Client)
+
+// WithTimeout bounds each HTTP request made to the webserver.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.httpClient.Timeout = timeout
+	}
+}
+
+// WithRetries retries failed requests up to retries times. The delay between
+// two attempts starts at backoff and doubles after each attempt.
+func WithRetries(retries int, backoff time.Duration) Option {
+	return func(c *Client) {
+		c.retries = retries
+		c.backoff = backoff
+	}
+}
+
+// WithHTTPClient replaces the HTTP client used to reach the webserver.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+func NewClient(baseURL string, opts ...Option) *Client {
+	c := &Client{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		backoff:    500 * time.Millisecond,
+	}
+	c.Routes = Routes{Getter: c}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// BaseURL returns the address of the webserver.
+func (c *Client) BaseURL() string {
+	return c.baseURL
+}
+
+// Get fetches the route and decodes its JSON payload into v.
+func (c *Client) Get(ctx context.Context, route string, v any) error {
+	data, err := c.GetRaw(ctx, route)
+	if err != nil {
+		return err
+	}
+	return Decode(route, data, v)
+}
+
+// GetRaw fetches the route and returns its payload without decoding it.
+// Connection failures and 5xx responses are retried.
+func (c *Client) GetRaw(ctx context.Context, route string) ([]byte, error) {
+	backoff := c.backoff
+	for attempt := 0; ; attempt++ {
+		data, err := c.get(ctx, route)
+		if err == nil || attempt >= c.retries || !retryable(err) {
+			return data, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, err
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+func (c *Client) get(ctx context.Context, route string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/"+strings.TrimPrefix(route, "/"), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, &ConnectionError{Route: route, Err: err}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, &HTTPError{Route: route, StatusCode: resp.StatusCode, Status: resp.Status}
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, &ConnectionError{Route: route, Err: err}
+	}
+	return data, nil
+}
+
+func retryable(err error) bool {
+	var connErr *ConnectionError
+	if errors.As(err, &connErr) {
+		// Giving up was decided by the caller, not by the webserver.
+		return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+	}
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode >= 500
+	}
+	return false
+}
+
+// Decode decodes the payload of a route into v. FRM answers with an empty
+// object instead of an empty array when there is nothing to report, in which
+// case v is left untouched.
+func Decode(route string, data []byte, v any) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("{}")) {
+		return nil
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return &DecodeError{Route: route, Err: err}
+	}
+	return nil
+}
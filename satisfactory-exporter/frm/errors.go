@@ -0,0 +1,47 @@
+package frm
+
+import (
+	"fmt"
+)
+
+// ConnectionError is returned when the webserver could not be reached or the
+// response could not be read.
+type ConnectionError struct {
+	Route string
+	Err   error
+}
+
+func (e *ConnectionError) Error() string {
+	return fmt.Sprintf("frm: connecting for %s: %s", e.Route, e.Err)
+}
+
+func (e *ConnectionError) Unwrap() error {
+	return e.Err
+}
+
+// HTTPError is returned when the webserver answers with a status other than
+// 200 OK.
+type HTTPError struct {
+	Route      string
+	StatusCode int
+	Status     string
+}
+
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("frm: unexpected status for %s: %s", e.Route, e.Status)
+}
+
+// DecodeError is returned when the payload of a route does not match the
+// expected structure.
+type DecodeError struct {
+	Route string
+	Err   error
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("frm: decoding %s: %s", e.Route, e.Err)
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
@@ -1,22 +1,15 @@
-package exporter
-
-var (
-	SmelterPower             = 4.0
-	ConstructorPower         = 4.0
-	AssemblerPower           = 15.0
-	ManufacturerPower        = 55.0
-	BlenderPower             = 75.0
-	RefineryPower            = 30.0
-	ParticleAcceleratorPower = 1500.0
-)
+package frm
 
 type BuildingDetail struct {
+	Id           string       `json:"ID"`
 	Building     string       `json:"Name"`
 	Location     Location     `json:"location"`
 	Recipe       string       `json:"Recipe"`
 	Production   []Production `json:"production"`
 	Ingredients  []Ingredient `json:"ingredients"`
 	ManuSpeed    float64      `json:"ManuSpeed"`
+	PowerShards  float64      `json:"PowerShards"`
+	Somersloops  float64      `json:"Somersloops"`
 	IsConfigured bool         `json:"IsConfigured"`
 	IsProducing  bool         `json:"IsProducing"`
 	IsPaused     bool         `json:"IsPaused"`
@@ -26,6 +19,7 @@ type BuildingDetail struct {
 
 type Production struct {
 	Name        string  `json:"Name"`
+	Amount      float64 `json:"Amount"` // items waiting in the output buffer
 	CurrentProd float64 `json:"CurrentProd"`
 	MaxProd     float64 `json:"MaxProd"`
 	ProdPercent float64 `json:"ProdPercent"`
@@ -33,7 +27,23 @@ type Production struct {
 
 type Ingredient struct {
 	Name            string  `json:"Name"`
+	Amount          float64 `json:"Amount"` // items waiting in the input buffer
 	CurrentConsumed float64 `json:"CurrentConsumed"`
 	MaxConsumed     float64 `json:"MaxConsumed"`
 	ConsPercent     float64 `json:"ConsPercent"`
 }
+
+type ExtractorDetail struct {
+	Id           string       `json:"ID"`
+	Building     string       `json:"Name"`
+	Location     Location     `json:"location"`
+	Recipe       string       `json:"Recipe"`
+	Production   []Production `json:"production"`
+	Purity       string       `json:"Purity"` // Impure, Normal, Pure
+	ManuSpeed    float64      `json:"ManuSpeed"`
+	IsConfigured bool         `json:"IsConfigured"`
+	IsProducing  bool         `json:"IsProducing"`
+	IsPaused     bool         `json:"IsPaused"`
+	CircuitID    int          `json:"CircuitID"`
+	PowerInfo    PowerInfo    `json:"PowerInfo"`
+}
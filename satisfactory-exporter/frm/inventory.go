@@ -0,0 +1,14 @@
+package frm
+
+type InventoryItem struct {
+	Name      string  `json:"Name"`
+	Amount    float64 `json:"Amount"`
+	MaxAmount float64 `json:"MaxAmount"`
+}
+
+type StorageDetails struct {
+	Id        string          `json:"ID"`
+	Name      string          `json:"Name"`
+	Location  Location        `json:"location"`
+	Inventory []InventoryItem `json:"Inventory"`
+}
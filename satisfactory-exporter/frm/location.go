@@ -0,0 +1,32 @@
+package frm
+
+import (
+	"math"
+)
+
+type Location struct {
+	X        float64 `json:"x"`
+	Y        float64 `json:"y"`
+	Z        float64 `json:"z"`
+	Rotation int     `json:"rotation"`
+}
+
+// Calculates if a location is nearby another.
+// From observation, 5000 units is "good enough" to be considered nearby.
+func (l *Location) IsNearby(other Location) bool {
+	x := l.X - other.X
+	y := l.Y - other.Y
+	z := l.Z - other.Z
+
+	dist := math.Sqrt(math.Pow(x, 2) + math.Pow(y, 2) + math.Pow(z, 2))
+	return dist <= 5000
+}
+
+// Calculates if this location is roughly facing the same way as another
+func (l *Location) IsSameDirection(other Location) bool {
+	diff := math.Mod(math.Abs(float64(l.Rotation-other.Rotation)), 360)
+	if diff > 180 {
+		diff = 360 - diff
+	}
+	return diff <= 90
+}
@@ -0,0 +1,18 @@
+package frm
+
+type TagColor struct {
+	R float64 `json:"R"`
+	G float64 `json:"G"`
+	B float64 `json:"B"`
+	A float64 `json:"A"`
+}
+
+type PlayerDetails struct {
+	ID         float64  `json:"ID"`
+	PlayerName string   `json:"PlayerName"`
+	PlayerHP   float64  `json:"PlayerHP"`
+	Dead       bool     `json:"Dead"`
+	PingTime   float64  `json:"PingTime"`
+	Location   Location `json:"Location"`
+	TagColor   TagColor `json:"TagColor"`
+}
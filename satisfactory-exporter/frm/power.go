@@ -0,0 +1,38 @@
+package frm
+
+type PowerInfo struct {
+	CircuitId     float64 `json:"ID"`
+	PowerConsumed float64 `json:"PowerConsumed"`
+}
+
+type PowerDetails struct {
+	CircuitId           float64 `json:"CircuitID"`
+	PowerConsumed       float64 `json:"PowerConsumed"`
+	PowerCapacity       float64 `json:"PowerCapacity"`
+	PowerMaxConsumed    float64 `json:"PowerMaxConsumed"`
+	BatteryDifferential float64 `json:"BatteryDifferential"`
+	BatteryPercent      float64 `json:"BatteryPercent"`
+	BatteryCapacity     float64 `json:"BatteryCapacity"`
+	BatteryTimeEmpty    string  `json:"BatteryTimeEmpty"`
+	BatteryTimeFull     string  `json:"BatteryTimeFull"`
+	FuseTriggered       bool    `json:"FuseTriggered"`
+}
+
+type GeneratorDetails struct {
+	Id                       string          `json:"ID"`
+	Name                     string          `json:"Name"` // Biomass Burner, Coal Generator, Fuel Generator, Nuclear Power Plant, Geothermal Generator
+	Location                 Location        `json:"location"`
+	BaseProd                 float64         `json:"BaseProd"`
+	RegulatedDemandProd      float64         `json:"RegulatedDemandProd"` // current production, following the demand of the circuit
+	PowerProductionPotential float64         `json:"PowerProductionPotential"`
+	LoadPercentage           float64         `json:"LoadPercentage"`
+	IsFullSpeed              bool            `json:"IsFullSpeed"`
+	CanStart                 bool            `json:"CanStart"`
+	CurrentFuel              string          `json:"CurrentFuel"`
+	FuelInventory            []InventoryItem `json:"FuelInventory"`
+	SupplementalResource     string          `json:"SupplementalResource"`
+	SupplementalAmount       float64         `json:"SupplementalAmount"`
+	SupplementalMaxAmount    float64         `json:"SupplementalMaxAmount"`
+	WasteInventory           []InventoryItem `json:"WasteInventory"`
+	PowerInfo                PowerInfo       `json:"PowerInfo"`
+}
@@ -0,0 +1,127 @@
+package frm
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// Routes of the FRM webserver.
+const (
+	RoutePower        = "getPower"
+	RouteGenerators   = "getGenerators"
+	RouteFactory      = "getFactory"
+	RouteExtractor    = "getExtractor"
+	RouteProdStats    = "getProdStats"
+	RouteStorageInv   = "getStorageInv"
+	RouteWorldInv     = "getWorldInv"
+	RouteTrains       = "getTrains"
+	RouteTrainStation = "getTrainStation"
+	RouteVehicles     = "getVehicles"
+	RouteTruckStation = "getTruckStation"
+	RouteDroneStation = "getDroneStation"
+	RouteDrone        = "getDrone"
+	RoutePlayer       = "getPlayer"
+)
+
+// Getter decodes the JSON payload of a route into v. It is implemented by
+// Client, and by the caches of its payloads.
+type Getter interface {
+	Get(ctx context.Context, route string, v any) error
+}
+
+// Routes gives typed access to the routes of a Getter.
+type Routes struct {
+	Getter
+}
+
+// GetRecords returns the raw JSON records of a route, all their fields
+// included. It serves the routes without a typed method as well.
+func (r Routes) GetRecords(ctx context.Context, route string) ([]json.RawMessage, error) {
+	records := []json.RawMessage{}
+	err := r.Get(ctx, route, &records)
+	return records, err
+}
+
+func (r Routes) GetPower(ctx context.Context) ([]PowerDetails, error) {
+	details := []PowerDetails{}
+	err := r.Get(ctx, RoutePower, &details)
+	return details, err
+}
+
+func (r Routes) GetGenerators(ctx context.Context) ([]GeneratorDetails, error) {
+	details := []GeneratorDetails{}
+	err := r.Get(ctx, RouteGenerators, &details)
+	return details, err
+}
+
+func (r Routes) GetFactory(ctx context.Context) ([]BuildingDetail, error) {
+	details := []BuildingDetail{}
+	err := r.Get(ctx, RouteFactory, &details)
+	return details, err
+}
+
+func (r Routes) GetExtractor(ctx context.Context) ([]ExtractorDetail, error) {
+	details := []ExtractorDetail{}
+	err := r.Get(ctx, RouteExtractor, &details)
+	return details, err
+}
+
+func (r Routes) GetProdStats(ctx context.Context) ([]ProductionDetails, error) {
+	details := []ProductionDetails{}
+	err := r.Get(ctx, RouteProdStats, &details)
+	return details, err
+}
+
+func (r Routes) GetStorageInv(ctx context.Context) ([]StorageDetails, error) {
+	details := []StorageDetails{}
+	err := r.Get(ctx, RouteStorageInv, &details)
+	return details, err
+}
+
+func (r Routes) GetWorldInv(ctx context.Context) ([]InventoryItem, error) {
+	details := []InventoryItem{}
+	err := r.Get(ctx, RouteWorldInv, &details)
+	return details, err
+}
+
+func (r Routes) GetTrains(ctx context.Context) ([]TrainDetails, error) {
+	details := []TrainDetails{}
+	err := r.Get(ctx, RouteTrains, &details)
+	return details, err
+}
+
+func (r Routes) GetTrainStation(ctx context.Context) ([]TrainStationDetails, error) {
+	details := []TrainStationDetails{}
+	err := r.Get(ctx, RouteTrainStation, &details)
+	return details, err
+}
+
+func (r Routes) GetVehicles(ctx context.Context) ([]VehicleDetails, error) {
+	details := []VehicleDetails{}
+	err := r.Get(ctx, RouteVehicles, &details)
+	return details, err
+}
+
+func (r Routes) GetTruckStation(ctx context.Context) ([]VehicleStationDetails, error) {
+	details := []VehicleStationDetails{}
+	err := r.Get(ctx, RouteTruckStation, &details)
+	return details, err
+}
+
+func (r Routes) GetDroneStation(ctx context.Context) ([]DroneStationDetails, error) {
+	details := []DroneStationDetails{}
+	err := r.Get(ctx, RouteDroneStation, &details)
+	return details, err
+}
+
+func (r Routes) GetDrone(ctx context.Context) ([]DroneDetails, error) {
+	details := []DroneDetails{}
+	err := r.Get(ctx, RouteDrone, &details)
+	return details, err
+}
+
+func (r Routes) GetPlayer(ctx context.Context) ([]PlayerDetails, error) {
+	details := []PlayerDetails{}
+	err := r.Get(ctx, RoutePlayer, &details)
+	return details, err
+}
@@ -0,0 +1,41 @@
+package frm
+
+type TrainCar struct {
+	Name           string  `json:"Name"`
+	TotalMass      float64 `json:"TotalMass"`
+	PayloadMass    float64 `json:"PayloadMass"`
+	MaxPayloadMass float64 `json:"MaxPayloadMass"`
+}
+
+type TrainDetails struct {
+	TrainName       string     `json:"Name"`
+	Location        Location   `json:"location"`
+	PowerConsumed   float64    `json:"PowerConsumed"`
+	TrainStation    string     `json:"TrainStation"`
+	Derailed        bool       `json:"Derailed"`
+	Status          string     `json:"Status"` //"Self-Driving",
+	TrainConsist    []TrainCar `json:"TrainConsist"`
+	ForwardSpeed    float64    `json:"ForwardSpeed"`
+	ThrottlePercent float64    `json:"ThrottlePercent"`
+	TotalMass       float64    `json:"TotalMass"`
+	PayloadMass     float64    `json:"PayloadMass"`
+	MaxPayloadMass  float64    `json:"MaxPayloadMass"`
+	PowerInfo       PowerInfo  `json:"PowerInfo"`
+}
+
+type CargoPlatform struct {
+	Id            string          `json:"ID"`
+	LoadingDock   string          `json:"LoadingDock"`
+	TransferRate  float64         `json:"TransferRate"`
+	LoadingStatus string          `json:"LoadingStatus"` // Idle, Loading, Unloading
+	LoadingMode   string          `json:"LoadingMode"`
+	Inventory     []InventoryItem `json:"Inventory"`
+}
+
+type TrainStationDetails struct {
+	Id             string          `json:"ID"`
+	Name           string          `json:"Name"`
+	Location       Location        `json:"location"`
+	CargoPlatforms []CargoPlatform `json:"CargoPlatforms"`
+	PowerInfo      PowerInfo       `json:"PowerInfo"`
+}
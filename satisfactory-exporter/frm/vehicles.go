@@ -0,0 +1,22 @@
+package frm
+
+type VehicleDetails struct {
+	Id           string   `json:"ID"`
+	VehicleType  string   `json:"Name"`
+	Location     Location `json:"location"`
+	ForwardSpeed float64  `json:"ForwardSpeed"`
+	AutoPilot    bool     `json:"AutoPilot"`
+	Fuel         []Fuel   `json:"Fuel"`
+	PathName     string   `json:"PathName"`
+}
+
+type Fuel struct {
+	Name   string  `json:"Name"`
+	Amount float64 `json:"Amount"`
+}
+
+type VehicleStationDetails struct {
+	Name      string    `json:"Name"`
+	Location  Location  `json:"location"`
+	PowerInfo PowerInfo `json:"PowerInfo"`
+}
@@ -1,13 +1,22 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"flag"
+	"fmt"
 	"net/http"
+	"net/url"
 	"strings"
 	"time"
 
+	"github.com/go-kit/log"
 	"github.com/go-kit/log/level"
 	"github.com/justereseau/satisfactory-metrics/satisfactory-exporter/exporter"
+	"github.com/justereseau/satisfactory-metrics/satisfactory-exporter/frm"
+	"github.com/justereseau/satisfactory-metrics/satisfactory-exporter/projection"
+	"github.com/justereseau/satisfactory-metrics/satisfactory-exporter/tiles"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/prometheus/common/promlog"
@@ -24,9 +33,119 @@ const (
 var (
 	listenAddress = flag.String("web.listen-address", "127.0.0.1:9100", "Address to listen on for web interface and telemetry.")
 	logLevel      = flag.String("log.level", "info", "Only log messages with the given severity or above. One of: [debug, info, warn, error, none]")
+	configFile    = flag.String("config.file", "", "Configuration file listing the Ficsit Remote Monitoring webservers to monitor, which override -frm.listen-address, and the labeling policy of the metrics.")
 	frmApiAddress = flag.String("frm.listen-address", "http://localhost:8080", "Address of Ficsit Remote Monitoring webserver")
+	frmTimeout    = flag.Duration("frm.timeout", 10*time.Second, "Timeout of a single request to the Ficsit Remote Monitoring webserver")
+	frmRetries    = flag.Int("frm.retries", 2, "Number of retries of a failed request to the Ficsit Remote Monitoring webserver")
+	frmBackoff    = flag.Duration("frm.retry-backoff", 500*time.Millisecond, "Delay before the first retry, doubled after each attempt")
+	pollInterval  = flag.Duration("frm.poll-interval", 0, "Interval between two background polls of a Ficsit Remote Monitoring route. 0 disables the background polling and queries the webserver on every scrape.")
+	pollIntervals = flag.String("frm.poll-intervals", "", "Per route override of the poll interval, as a comma separated list of route=duration (e.g. getFactory=1m,getTrains=5s)")
+
+	collectorTimeout  = flag.Duration("collector.timeout", 10*time.Second, "Maximum duration of a collector during a scrape. 0 disables the timeout.")
+	collectorTimeouts = flag.String("collector.timeouts", "", "Per collector override of the timeout, as a comma separated list of collector=duration (e.g. factory_building=20s,train=2s), 0 disables the timeout of a collector")
+
+	gameDataFile  = flag.String("gamedata.file", "", "Game data file describing the power of the buildings, replaces the embedded one. See exporter/gamedata.yml.")
+	mapProjection = flag.String("map.projection", "geomap", "Projection of the x, y and z location labels and of the positions. One of: [raw, geomap, pixel]")
+	mapPixelZoom  = flag.Int("map.pixel-zoom", projection.DefaultPixelZoom, "Zoom level of the /maps/{z}/{x}/{y}.png tiles the pixel projection is computed for")
+
+	tilesDir          = flag.String("tiles.dir", "", "Directory of map tiles, laid out as {z}/{x}/{y}.png, served on /maps/{z}/{x}/{y}.png")
+	tilesUpstream     = flag.String("tiles.upstream", "", "URL of the upstream map tiles, where {z}, {x} and {y} are replaced by the tile coordinates (e.g. https://static.satisfactory-calculator.com/imgMap/realisticLayer/Stable/{z}/{x}/{y}.png)")
+	tilesTimeout      = flag.Duration("tiles.upstream-timeout", 60*time.Second, "Timeout of a request to the upstream map tiles")
+	tilesCacheDir     = flag.String("tiles.cache.dir", "", "Directory caching the upstream map tiles. Empty disables the cache.")
+	tilesCacheMaxSize = flag.Int64("tiles.cache.max-size", 10<<30, "Maximum size of the map tile cache in bytes, the least recently used tiles are evicted first. 0 disables the limit.")
+	tilesCacheTTL     = flag.Duration("tiles.cache.ttl", 30*24*time.Hour, "Duration after which a cached map tile is revalidated with the upstream. 0 disables the expiration.")
+
+	analysisThreshold        = flag.Float64("collector.analysis.threshold", 95, "Production or consumption rate, in percent, below which the analysis collector considers a building is not running properly")
+	analysisGeohashPrecision = flag.Int("collector.analysis.geohash-precision", 4, "Length of the geohash prefix used as area by the analysis collector")
+
+	storagePerContainer = flag.Bool("collector.storage.per-container", false, "Expose the content and fill level of every storage container. Creates one series per container and item.")
 )
 
+// Target production of the critical items, from the configuration file.
+var criticalItems = map[string]float64{}
+
+// Names of the power circuits of -frm.listen-address, from the configuration
+// file.
+var circuitNames = map[float64]string{}
+
+// parseDurations reads a comma separated list of name=duration.
+func parseDurations(s string) (map[string]time.Duration, error) {
+	durations := map[string]time.Duration{}
+	if s == "" {
+		return durations, nil
+	}
+	for _, item := range strings.Split(s, ",") {
+		name, value, found := strings.Cut(item, "=")
+		if !found {
+			return nil, fmt.Errorf("invalid duration %q, expected name=duration", item)
+		}
+		duration, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid duration for %s: %w", name, err)
+		}
+		durations[strings.TrimPrefix(name, "/")] = duration
+	}
+	return durations, nil
+}
+
+func newClient(address string) *frm.Client {
+	return frm.NewClient(address, frm.WithTimeout(*frmTimeout), frm.WithRetries(*frmRetries, *frmBackoff))
+}
+
+// registerTarget registers the collectors enabled for the scrape of a target.
+func registerTarget(ctx context.Context, registerer prometheus.Registerer, target *exporter.Target, enabledCollectors string, timeouts map[string]time.Duration, policy *exporter.LabelPolicy, logger log.Logger) {
+	if target.Poller != nil {
+		registerer.MustRegister(target.Poller)
+	}
+
+	level.Debug(logger).Log("msg", "Enabled collectors: ", enabledCollectors)
+	if enabledCollectors == "all" || enabledCollectors == "" {
+		enabledCollectors = "production,power,generator,factory_building,extractor,storage,world_inventory,vehicle,drone,drone_station,vehicle_station,train,train_station,player,analysis,balance"
+	}
+
+	source := target.Source
+	collectors := map[string]exporter.Collector{}
+	for _, collector := range strings.Split(enabledCollectors, ",") {
+		switch collector {
+		case "production":
+			collectors[collector] = exporter.NewProductionCollector(source, logger)
+		case "power":
+			collectors[collector] = exporter.NewPowerCollector(source, target.Power, target.Circuits, logger)
+		case "generator":
+			collectors[collector] = exporter.NewGeneratorCollector(source, logger)
+		case "factory_building":
+			collectors[collector] = exporter.NewFactoryBuildingCollector(source, target.Circuits, logger)
+		case "extractor":
+			collectors[collector] = exporter.NewExtractorCollector(source, target.Circuits, logger)
+		case "storage":
+			collectors[collector] = exporter.NewStorageCollector(source, *storagePerContainer, logger)
+		case "world_inventory":
+			collectors[collector] = exporter.NewWorldInventoryCollector(source, logger)
+		case "vehicle":
+			collectors[collector] = exporter.NewVehicleCollector(source, target.Vehicles, logger)
+		case "drone":
+			collectors[collector] = exporter.NewDroneCollector(source, logger)
+		case "drone_station":
+			collectors[collector] = exporter.NewDroneStationCollector(source, target.Circuits, logger)
+		case "vehicle_station":
+			collectors[collector] = exporter.NewVehicleStationCollector(source, target.Circuits, logger)
+		case "train":
+			collectors[collector] = exporter.NewTrainCollector(source, target.Trains, target.Circuits, logger)
+		case "train_station":
+			collectors[collector] = exporter.NewTrainStationCollector(source, target.Platforms, target.Circuits, logger)
+		case "player":
+			collectors[collector] = exporter.NewPlayerCollector(source, logger)
+		case "balance":
+			collectors[collector] = exporter.NewBalanceCollector(source, criticalItems, logger)
+		case "analysis":
+			collectors[collector] = exporter.NewAnalysisCollector(source, *analysisThreshold, *analysisGeohashPrecision, logger)
+		default:
+			level.Warn(logger).Log("msg", "Unknown collector", "collector", collector)
+		}
+	}
+	registerer.MustRegister(exporter.NewScrapeCollector(ctx, collectors, *collectorTimeout, timeouts, policy, logger))
+}
+
 func main() {
 	// Get parameters
 	flag.Parse()
@@ -39,6 +158,85 @@ func main() {
 
 	prometheus.MustRegister(version.NewCollector(exporter_name + "_exporter"))
 
+	timeouts, err := parseDurations(*collectorTimeouts)
+	if err != nil {
+		level.Error(logger).Log("msg", "Failed to parse collector timeouts.", "err", err)
+		return
+	}
+
+	if *gameDataFile != "" {
+		if err := exporter.LoadGameData(*gameDataFile); err != nil {
+			level.Error(logger).Log("msg", "Failed to load game data file.", "err", err)
+			return
+		}
+	}
+
+	projection.DefaultPixelZoom = *mapPixelZoom
+	exporter.MapProjection, err = projection.Get(*mapProjection)
+	if err != nil {
+		level.Error(logger).Log("msg", "Failed to select the map projection.", "err", err)
+		return
+	}
+
+	intervals, err := parseDurations(*pollIntervals)
+	if err != nil {
+		level.Error(logger).Log("msg", "Failed to parse poll intervals.", "err", err)
+		return
+	}
+	for route, interval := range intervals {
+		if interval <= 0 {
+			level.Error(logger).Log("msg", "Poll intervals must be positive.", "route", route, "interval", interval)
+			return
+		}
+	}
+	if *pollInterval > 0 {
+		level.Info(logger).Log("msg", "Background polling enabled.", "interval", *pollInterval)
+	}
+
+	// Without servers in the configuration file, a single server is monitored
+	// and its metrics carry no server label.
+	targets := []*exporter.Target{}
+	labeled := false
+	var policy *exporter.LabelPolicy
+	if *configFile != "" {
+		config, err := exporter.LoadConfig(*configFile)
+		if err != nil {
+			level.Error(logger).Log("msg", "Failed to load configuration file.", "err", err)
+			return
+		}
+		policy, err = exporter.NewLabelPolicy(config.Labels)
+		if err != nil {
+			level.Error(logger).Log("msg", "Failed to load labeling policy.", "err", err)
+			return
+		}
+		criticalItems = config.Targets()
+		circuitNames = config.CircuitNames()
+		for _, s := range config.Servers {
+			targetLogger := log.With(logger, "server", s.Name)
+			targets = append(targets, exporter.NewTarget(context.Background(), s.Name, newClient(s.Address), *pollInterval, intervals, s.CircuitNames(), targetLogger))
+		}
+		labeled = len(targets) > 0
+		level.Info(logger).Log("msg", "Configuration file loaded.", "servers", len(targets), "label_rules", len(config.Labels))
+	}
+	if !labeled {
+		targets = append(targets, exporter.NewTarget(context.Background(), "", newClient(*frmApiAddress), *pollInterval, intervals, circuitNames, logger))
+	}
+
+	var tileServer *tiles.Server
+	if *tilesDir != "" || *tilesUpstream != "" {
+		var cache *tiles.Cache
+		if *tilesCacheDir != "" {
+			cache, err = tiles.NewCache(*tilesCacheDir, *tilesCacheMaxSize, *tilesCacheTTL)
+			if err != nil {
+				level.Error(logger).Log("msg", "Failed to open the map tile cache.", "err", err)
+				return
+			}
+		}
+		tileServer = tiles.NewServer(*tilesDir, *tilesUpstream, cache, *tilesTimeout, log.With(logger, "component", "tiles"))
+		http.Handle("/maps/", tileServer)
+		level.Info(logger).Log("msg", "Serving map tiles.", "dir", *tilesDir, "upstream", *tilesUpstream, "cache", *tilesCacheDir)
+	}
+
 	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
 		w.Write([]byte(`<html>
 			<head><title>` + exporter_display_name + `</title></head>
@@ -58,46 +256,86 @@ func main() {
 		level.Debug(logger).Log("msg", "Starting scrape")
 
 		registry := prometheus.NewRegistry()
+		if tileServer != nil {
+			registry.MustRegister(tileServer)
+		}
+		for _, target := range targets {
+			var registerer prometheus.Registerer = registry
+			targetLogger := logger
+			if labeled {
+				registerer = prometheus.WrapRegistererWith(prometheus.Labels{"server": target.Name}, registry)
+				targetLogger = log.With(logger, "server", target.Name)
+			}
+			registerTarget(r.Context(), registerer, target, r.URL.Query().Get("collect"), timeouts, policy, targetLogger)
+		}
+
+		h := promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+		h.ServeHTTP(w, r)
+		level.Debug(logger).Log("msg", "Scrape done.", "duration", time.Since(start).Seconds())
+	})
 
-		// Get enabled collectors from request
-		enabledCollectors := r.URL.Query().Get("collect")
-		level.Debug(logger).Log("msg", "Enabled collectors: ", enabledCollectors)
+	// Map overlay, every feature of a layer carries the name of its server
+	// when a configuration file is used.
+	http.HandleFunc("/api/geojson/", func(w http.ResponseWriter, r *http.Request) {
+		layer := strings.TrimPrefix(r.URL.Path, "/api/geojson/")
+		w.Header().Set("Access-Control-Allow-Origin", "*")
 
-		if enabledCollectors == "all" || enabledCollectors == "" {
-			enabledCollectors = "production,power,factory_building,vehicle,drone_station,vehicle_station,train,train_station,player"
+		features := []exporter.Feature{}
+		for _, target := range targets {
+			targetFeatures, err := exporter.GeoJSONLayer(r.Context(), target.Source, layer)
+			if errors.Is(err, exporter.ErrUnknownLayer) {
+				http.Error(w, fmt.Sprintf("unknown layer %q, expected one of: %s", layer, strings.Join(exporter.GeoJSONLayers(), ", ")), http.StatusNotFound)
+				return
+			}
+			if err != nil {
+				level.Error(logger).Log("msg", "Failed to build GeoJSON layer.", "layer", layer, "server", target.Name, "err", err)
+				http.Error(w, "failed to fetch the layer", http.StatusBadGateway)
+				return
+			}
+			for _, feature := range targetFeatures {
+				if labeled {
+					feature.Properties["server"] = target.Name
+				}
+				features = append(features, feature)
+			}
 		}
-		for _, collector := range strings.Split(enabledCollectors, ",") {
-			switch collector {
-			case "production":
-				registry.MustRegister(exporter.NewProductionCollector(*frmApiAddress, logger))
-			case "power":
-				registry.MustRegister(exporter.NewPowerCollector(*frmApiAddress, logger))
-			case "factory_building":
-				registry.MustRegister(exporter.NewFactoryBuildingCollector(*frmApiAddress, logger))
-			case "vehicle":
-				registry.MustRegister(exporter.NewVehicleCollector(*frmApiAddress, logger))
-			case "drone_station":
-				registry.MustRegister(exporter.NewDroneStationCollector(*frmApiAddress, logger))
-			case "vehicle_station":
-				registry.MustRegister(exporter.NewVehicleStationCollector(*frmApiAddress, logger))
-			case "train":
-				registry.MustRegister(exporter.NewTrainCollector(*frmApiAddress, logger))
-			case "train_station":
-				registry.MustRegister(exporter.NewTrainStationCollector(*frmApiAddress, logger))
-			case "player":
-				registry.MustRegister(exporter.NewPlayerCollector(*frmApiAddress, logger))
-			default:
-				level.Warn(logger).Log("msg", "Unknown collector", "collector", collector)
+
+		w.Header().Set("Content-Type", "application/geo+json")
+		json.NewEncoder(w).Encode(exporter.NewFeatureCollection(features))
+	})
+
+	// Blackbox exporter style endpoint, the target is either the name of a
+	// server of the configuration file or the address of a FRM webserver.
+	http.HandleFunc("/probe", func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		name := r.URL.Query().Get("target")
+		level.Debug(logger).Log("msg", "Starting probe", "target", name)
+
+		var target *exporter.Target
+		for _, t := range targets {
+			if labeled && t.Name == name {
+				target = t
+			}
+		}
+		if target == nil {
+			if u, err := url.Parse(name); err != nil || u.Scheme == "" || u.Host == "" {
+				http.Error(w, "target must be a configured server or the address of a FRM webserver", http.StatusBadRequest)
+				return
 			}
+			target = exporter.NewTarget(r.Context(), name, newClient(name), 0, nil, nil, logger)
 		}
 
+		registry := prometheus.NewRegistry()
+		registerer := prometheus.WrapRegistererWith(prometheus.Labels{"server": name}, registry)
+		registerTarget(r.Context(), registerer, target, r.URL.Query().Get("collect"), timeouts, policy, log.With(logger, "server", name))
+
 		h := promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
 		h.ServeHTTP(w, r)
-		level.Debug(logger).Log("msg", "Scrape done.", "duration", time.Since(start).Seconds())
+		level.Debug(logger).Log("msg", "Probe done.", "target", name, "duration", time.Since(start).Seconds())
 	})
 
 	level.Info(logger).Log("msg", "Starting to listen.", "address", *listenAddress)
-	err := http.ListenAndServe(*listenAddress, nil)
+	err = http.ListenAndServe(*listenAddress, nil)
 	if err != nil {
 		level.Error(logger).Log("msg", "Failed to start http server.", "err", err)
 	}
@@ -0,0 +1,95 @@
+// Package projection converts in-game coordinates, in Unreal units, to the
+// coordinates used by map renderers.
+package projection
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// Point is a projected location.
+type Point struct {
+	X float64
+	Y float64
+	Z float64
+}
+
+// Projection converts in-game locations to map coordinates.
+type Projection interface {
+	// Project converts a location in Unreal units.
+	Project(x, y, z float64) Point
+}
+
+var projections = map[string]func() Projection{
+	"raw":    func() Projection { return Raw{} },
+	"geomap": func() Projection { return Geomap{} },
+	"pixel":  func() Projection { return Pixel{Zoom: DefaultPixelZoom} },
+}
+
+// Get returns the projection registered under name.
+func Get(name string) (Projection, error) {
+	p, ok := projections[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown projection %q, expected one of: %s", name, strings.Join(Names(), ", "))
+	}
+	return p(), nil
+}
+
+// Names returns the names of the registered projections.
+func Names() []string {
+	names := []string{}
+	for name := range projections {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Raw keeps the Unreal units.
+type Raw struct{}
+
+func (Raw) Project(x, y, z float64) Point {
+	return Point{X: x, Y: y, Z: z}
+}
+
+// Geomap maps the world onto longitude (X) and latitude (Y), so that Grafana
+// Geomap can place the locations on the map tiles. Z is kept in Unreal units.
+type Geomap struct{}
+
+func (Geomap) Project(x, y, z float64) Point {
+	return Point{
+		X: x*0.000239930467 - 79.70308527,
+		Y: -y*0.0001413589137 + 36.97720935,
+		Z: z,
+	}
+}
+
+// Bounds of the map served by the /maps/{z}/{x}/{y}.png tiles, in Unreal
+// units. The map is a square, tiles are 256 pixels wide.
+const (
+	MapMinX     = -324698.832031
+	MapMinY     = -375000.0
+	MapSize     = 750000.0
+	MapTileSize = 256
+)
+
+// Zoom level of the pixel projection returned by Get.
+var DefaultPixelZoom = 8
+
+// Pixel maps the world onto the pixels of the /maps/{z}/{x}/{y}.png tiles at
+// the given zoom level, with the origin at the top left corner of the map.
+// Z is kept in Unreal units.
+type Pixel struct {
+	Zoom int
+}
+
+func (p Pixel) Project(x, y, z float64) Point {
+	scale := math.Ldexp(MapTileSize, p.Zoom) / MapSize
+	return Point{
+		X: (x - MapMinX) * scale,
+		Y: (y - MapMinY) * scale,
+		Z: z,
+	}
+}
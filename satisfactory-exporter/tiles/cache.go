@@ -0,0 +1,177 @@
+// Package tiles serves the /maps/{z}/{x}/{y}.png map tiles, from a local
+// directory or from an upstream tile server through an on-disk cache.
+package tiles
+
+import (
+	"container/list"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Cache keeps tiles on disk, laid out as {z}/{x}/{y}.png below its directory,
+// and evicts the least recently used ones once its size exceeds maxSize.
+//
+// The modification time of a tile is the time it was last fetched or
+// revalidated from the upstream, it is used both to expire the tile after ttl
+// and for the conditional requests. A directory of tiles can therefore be used
+// to pre-seed the cache.
+type Cache struct {
+	dir     string
+	maxSize int64
+	ttl     time.Duration
+
+	mu      sync.Mutex
+	size    int64
+	lru     *list.List // front is the most recently used
+	entries map[string]*list.Element
+}
+
+type cacheEntry struct {
+	key     string
+	size    int64
+	modTime time.Time
+}
+
+// NewCache opens the cache in dir, indexing the tiles already there. A
+// maxSize or ttl of 0 disables the matching limit.
+func NewCache(dir string, maxSize int64, ttl time.Duration) (*Cache, error) {
+	c := &Cache{
+		dir:     dir,
+		maxSize: maxSize,
+		ttl:     ttl,
+		lru:     list.New(),
+		entries: map[string]*list.Element{},
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	found := []cacheEntry{}
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		if strings.HasSuffix(path, ".tmp") {
+			// Left over by an interrupted Put.
+			return os.Remove(path)
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		key, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		found = append(found, cacheEntry{key: filepath.ToSlash(key), size: info.Size(), modTime: info.ModTime()})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Without access times, the most recently fetched tiles are considered
+	// the most recently used.
+	sort.Slice(found, func(i, j int) bool { return found[i].modTime.Before(found[j].modTime) })
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i := range found {
+		c.entries[found[i].key] = c.lru.PushFront(&found[i])
+		c.size += found[i].size
+	}
+	c.evict()
+	return c, nil
+}
+
+func (c *Cache) path(key string) string {
+	return filepath.Join(c.dir, filepath.FromSlash(key))
+}
+
+// Get returns the path of a cached tile, when it was fetched and whether it is
+// still fresh.
+func (c *Cache) Get(key string) (path string, modTime time.Time, fresh bool, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[key]
+	if !ok {
+		return "", time.Time{}, false, false
+	}
+	c.lru.MoveToFront(e)
+	entry := e.Value.(*cacheEntry)
+	fresh = c.ttl == 0 || time.Since(entry.modTime) < c.ttl
+	return c.path(key), entry.modTime, fresh, true
+}
+
+// Put stores a tile fetched at modTime.
+func (c *Cache) Put(key string, data []byte, modTime time.Time) error {
+	path := c.path(key)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chtimes(tmp.Name(), modTime, modTime)
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.entries[key]; ok {
+		c.size -= e.Value.(*cacheEntry).size
+		c.lru.Remove(e)
+	}
+	c.entries[key] = c.lru.PushFront(&cacheEntry{key: key, size: int64(len(data)), modTime: modTime})
+	c.size += int64(len(data))
+	c.evict()
+	return nil
+}
+
+// Touch marks a cached tile as revalidated at modTime.
+func (c *Cache) Touch(key string, modTime time.Time) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[key]
+	if !ok {
+		return nil
+	}
+	e.Value.(*cacheEntry).modTime = modTime
+	return os.Chtimes(c.path(key), modTime, modTime)
+}
+
+// Stats returns the number of tiles and the size of the cache.
+func (c *Cache) Stats() (tiles int, size int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lru.Len(), c.size
+}
+
+// evict removes the least recently used tiles until the cache fits in
+// maxSize. The caller must hold c.mu.
+func (c *Cache) evict() {
+	for c.maxSize > 0 && c.size > c.maxSize && c.lru.Len() > 0 {
+		e := c.lru.Back()
+		entry := e.Value.(*cacheEntry)
+		c.lru.Remove(e)
+		delete(c.entries, entry.key)
+		c.size -= entry.size
+		os.Remove(c.path(entry.key))
+	}
+}
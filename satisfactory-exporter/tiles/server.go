@@ -0,0 +1,252 @@
+package tiles
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var tilePath = regexp.MustCompile(`^/maps/(\d+)/(\d+)/(\d+)\.png$`)
+
+// Results of a tile request, reported by the TileRequests metric.
+const (
+	ResultLocal       = "local"       // served from the local directory
+	ResultHit         = "hit"         // served from the cache
+	ResultRevalidated = "revalidated" // expired in the cache, unchanged upstream
+	ResultMiss        = "miss"        // fetched from the upstream
+	ResultStale       = "stale"       // expired in the cache, served as the upstream failed
+	ResultNotFound    = "not_found"
+	ResultError       = "error"
+)
+
+var (
+	TileRequests = prometheus.NewDesc(
+		"ficsit_tile_requests_total",
+		"Number of map tile requests, per result",
+		[]string{"result"},
+		nil,
+	)
+	TileCacheTiles = prometheus.NewDesc(
+		"ficsit_tile_cache_tiles",
+		"Number of map tiles in the cache",
+		nil,
+		nil,
+	)
+	TileCacheSize = prometheus.NewDesc(
+		"ficsit_tile_cache_size_bytes",
+		"Size of the map tiles in the cache",
+		nil,
+		nil,
+	)
+)
+
+// Server serves the map tiles on /maps/{z}/{x}/{y}.png.
+//
+// Tiles are looked up in the local directory first, then in the cache, and
+// finally fetched from the upstream. The upstream is a URL where {z}, {x} and
+// {y} are replaced by the coordinates of the tile. Expired tiles are
+// revalidated with a conditional request, and still served if the upstream
+// fails.
+type Server struct {
+	dir      string
+	upstream string
+	cache    *Cache
+	client   *http.Client
+	logger   log.Logger
+
+	mu       sync.Mutex
+	requests map[string]float64
+}
+
+// NewServer creates a tile server. dir, upstream and cache are all optional.
+func NewServer(dir string, upstream string, cache *Cache, timeout time.Duration, logger log.Logger) *Server {
+	return &Server{
+		dir:      dir,
+		upstream: upstream,
+		cache:    cache,
+		client:   &http.Client{Timeout: timeout},
+		logger:   logger,
+		requests: map[string]float64{},
+	}
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// Grafana fetches the tiles from the browser.
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Access-Control-Allow-Methods", "GET, HEAD, OPTIONS")
+	w.Header().Set("Access-Control-Allow-Headers", "DNT,User-Agent,X-Requested-With,If-Modified-Since,Cache-Control,Content-Type,Range")
+	switch r.Method {
+	case http.MethodOptions:
+		w.Header().Set("Access-Control-Max-Age", "1728000")
+		w.WriteHeader(http.StatusNoContent)
+		return
+	case http.MethodGet, http.MethodHead:
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	m := tilePath.FindStringSubmatch(r.URL.Path)
+	if m == nil {
+		s.count(ResultNotFound)
+		http.NotFound(w, r)
+		return
+	}
+	z, x, y := m[1], m[2], m[3]
+	key := z + "/" + x + "/" + y + ".png"
+
+	if s.dir != "" && s.serveFile(w, r, filepath.Join(s.dir, z, x, y+".png"), ResultLocal) {
+		return
+	}
+
+	var cached string
+	var modTime time.Time
+	if s.cache != nil {
+		path, t, fresh, ok := s.cache.Get(key)
+		if ok && fresh && s.serveFile(w, r, path, ResultHit) {
+			return
+		}
+		if ok {
+			cached, modTime = path, t
+		}
+	}
+
+	if s.upstream == "" {
+		if cached != "" && s.serveFile(w, r, cached, ResultStale) {
+			return
+		}
+		s.count(ResultNotFound)
+		http.NotFound(w, r)
+		return
+	}
+
+	data, notModified, err := s.fetch(r, z, x, y, modTime)
+	switch {
+	case err != nil:
+		level.Warn(s.logger).Log("msg", "Error fetching map tile", "tile", key, "err", err)
+		if cached != "" && s.serveFile(w, r, cached, ResultStale) {
+			return
+		}
+		s.count(ResultError)
+		http.Error(w, "failed to fetch the tile", http.StatusBadGateway)
+	case notModified:
+		now := time.Now()
+		if err := s.cache.Touch(key, now); err != nil {
+			level.Warn(s.logger).Log("msg", "Error revalidating cached map tile", "tile", key, "err", err)
+		}
+		if !s.serveFile(w, r, cached, ResultRevalidated) {
+			s.count(ResultError)
+			http.Error(w, "failed to read the tile", http.StatusInternalServerError)
+		}
+	case data == nil:
+		s.count(ResultNotFound)
+		http.NotFound(w, r)
+	default:
+		now := time.Now()
+		if s.cache != nil {
+			if err := s.cache.Put(key, data, now); err != nil {
+				level.Warn(s.logger).Log("msg", "Error caching map tile", "tile", key, "err", err)
+			}
+		}
+		s.count(ResultMiss)
+		s.setCacheControl(w)
+		w.Header().Set("Content-Type", "image/png")
+		http.ServeContent(w, r, key, now, bytes.NewReader(data))
+	}
+}
+
+// fetch gets a tile from the upstream. A nil payload without error means the
+// upstream does not have the tile. When modTime is set, the request is
+// conditional and notModified reports whether the cached tile is still valid.
+func (s *Server) fetch(r *http.Request, z, x, y string, modTime time.Time) (data []byte, notModified bool, err error) {
+	url := strings.NewReplacer("{z}", z, "{x}", x, "{y}", y).Replace(s.upstream)
+	req, err := http.NewRequestWithContext(r.Context(), http.MethodGet, url, nil)
+	if err != nil {
+		return nil, false, err
+	}
+	req.Header.Set("User-Agent", r.UserAgent())
+	if !modTime.IsZero() {
+		req.Header.Set("If-Modified-Since", modTime.UTC().Format(http.TimeFormat))
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, false, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		data, err := io.ReadAll(resp.Body)
+		return data, false, err
+	case http.StatusNotModified:
+		if modTime.IsZero() {
+			return nil, false, fmt.Errorf("unexpected status: %s", resp.Status)
+		}
+		return nil, true, nil
+	case http.StatusNotFound:
+		return nil, false, nil
+	default:
+		return nil, false, fmt.Errorf("unexpected status: %s", resp.Status)
+	}
+}
+
+// serveFile serves a tile from the disk, honouring the conditional headers of
+// the request. It returns false when the file cannot be read.
+func (s *Server) serveFile(w http.ResponseWriter, r *http.Request, path string, result string) bool {
+	f, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil || info.IsDir() {
+		return false
+	}
+
+	s.count(result)
+	s.setCacheControl(w)
+	w.Header().Set("Content-Type", "image/png")
+	http.ServeContent(w, r, path, info.ModTime(), f)
+	return true
+}
+
+func (s *Server) setCacheControl(w http.ResponseWriter) {
+	if s.cache != nil && s.cache.ttl > 0 {
+		w.Header().Set("Cache-Control", "public, max-age="+strconv.Itoa(int(s.cache.ttl.Seconds())))
+	}
+}
+
+func (s *Server) count(result string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests[result]++
+}
+
+func (s *Server) Describe(ch chan<- *prometheus.Desc) {}
+
+func (s *Server) Collect(ch chan<- prometheus.Metric) {
+	s.mu.Lock()
+	for result, count := range s.requests {
+		ch <- prometheus.MustNewConstMetric(TileRequests, prometheus.CounterValue, count, result)
+	}
+	s.mu.Unlock()
+
+	if s.cache != nil {
+		tiles, size := s.cache.Stats()
+		ch <- prometheus.MustNewConstMetric(TileCacheTiles, prometheus.GaugeValue, float64(tiles))
+		ch <- prometheus.MustNewConstMetric(TileCacheSize, prometheus.GaugeValue, float64(size))
+	}
+}
@@ -1,8 +1,10 @@
 module github.com/justereseau/satisfactory-metrics/satisfactory-metadata
 
-go 1.21.6
+go 1.22.0
 
 require (
-	github.com/benbjohnson/clock v1.3.5
+	github.com/justereseau/satisfactory-metrics/satisfactory-exporter v0.0.0
 	github.com/lib/pq v1.10.9
 )
+
+replace github.com/justereseau/satisfactory-metrics/satisfactory-exporter => ../satisfactory-exporter
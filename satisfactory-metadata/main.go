@@ -1,21 +1,24 @@
 package main
 
 import (
+	"context"
 	"database/sql"
 	"encoding/csv"
-	"encoding/json"
 	"flag"
 	"fmt"
 	"log"
-	"net/http"
 	"os"
+	"time"
 
+	"github.com/justereseau/satisfactory-metrics/satisfactory-exporter/frm"
 	_ "github.com/lib/pq"
 )
 
 // Define parameters
 var (
 	frmApiAddress = flag.String("frm.listen-address", "http://localhost:8080", "Address of Ficsit Remote Monitoring webserver")
+	frmTimeout    = flag.Duration("frm.timeout", 30*time.Second, "Timeout of a single request to the Ficsit Remote Monitoring webserver")
+	frmRetries    = flag.Int("frm.retries", 2, "Number of retries of a failed request to the Ficsit Remote Monitoring webserver")
 
 	pgHost      = flag.String("db.pghost", "postgres", "postgres hostname")
 	pgPort      = flag.Int("db.pgport", 5432, "postgres port")
@@ -67,7 +70,7 @@ func main() {
 		metrics = append(metrics, metric{name: "storageInv", route: "getStorageInv"})
 		metrics = append(metrics, metric{name: "worldInv", route: "getWorldInv"})
 		metrics = append(metrics, metric{name: "droneStation", route: "getDroneStation"})
-		metrics = append(metrics, metric{name: "generators", route: "getGenerator"})
+		metrics = append(metrics, metric{name: "generators", route: "getGenerators"})
 		metrics = append(metrics, metric{name: "drone", route: "getDrone"})
 		metrics = append(metrics, metric{name: "train", route: "getTrains"})
 		metrics = append(metrics, metric{name: "truck", route: "getVehicles"})
@@ -98,8 +101,9 @@ func main() {
 	}
 
 	// Pull metrics from Ficsit Remote Monitoring API
+	client := frm.NewClient(*frmApiAddress, frm.WithTimeout(*frmTimeout), frm.WithRetries(*frmRetries, time.Second))
 	for _, m := range metrics {
-		pullMetrics(db, m.name, m.route)
+		pullMetrics(db, client, m.name, m.route)
 	}
 }
 
@@ -123,26 +127,13 @@ func initDB(db *sql.DB) error {
 }
 
 // pull metrics from the Ficsit Remote Monitoring API
-func pullMetrics(db *sql.DB, metric string, route string) {
-	resp, err := http.Get(*frmApiAddress + "/" + route)
+func pullMetrics(db *sql.DB, client *frm.Client, metric string, route string) {
+	content, err := client.GetRecords(context.Background(), route)
 	if err != nil {
 		fmt.Println("Error while querying "+route, err)
 		return
 	}
 
-	var content []json.RawMessage
-	decoder := json.NewDecoder(resp.Body)
-	err = decoder.Decode(&content)
-	if err != nil {
-		// Try to found if it is an empty object
-		if _, ok := err.(*json.UnmarshalTypeError); ok {
-			return
-		}
-		fmt.Println("Error while decoding "+route+" response: ", err)
-		return
-	}
-	defer resp.Body.Close()
-
 	data := []string{}
 	for _, c := range content {
 		data = append(data, string(c[:]))